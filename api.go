@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 
 	"github.com/heroiclabs/nakama-common/api"
 	"github.com/heroiclabs/nakama/v3/apigrpc"
@@ -24,7 +25,15 @@ type grpcClient struct {
 }
 
 func NewGRPCClient(addr, serverKey string, secure bool, opts ...grpc.DialOption) (*Client, error) {
+	c := &Client{
+		addr:             addr,
+		serverKey:        serverKey,
+		secure:           secure,
+		clock:            systemClock{},
+		serverTimeOffset: &atomic.Int64{},
+	}
 	newOpts := append([]grpc.DialOption{}, opts...)
+	newOpts = append(newOpts, grpc.WithChainUnaryInterceptor(c.intercept))
 	if !secure {
 		newOpts = append(newOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	}
@@ -32,16 +41,11 @@ func NewGRPCClient(addr, serverKey string, secure bool, opts ...grpc.DialOption)
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial: %w", err)
 	}
-	client := apigrpc.NewNakamaClient(cc)
-	return &Client{
-		api: &grpcClient{
-			NakamaClient: client,
-			cc:           cc,
-		},
-		addr:      addr,
-		serverKey: serverKey,
-		secure:    secure,
-	}, nil
+	c.api = &grpcClient{
+		NakamaClient: apigrpc.NewNakamaClient(cc),
+		cc:           cc,
+	}
+	return c, nil
 }
 
 func (c *grpcClient) Close() error {
@@ -74,17 +78,21 @@ func NewHTTPClient(addr, serverKey string, secure bool, client *http.Client) *Cl
 	} else {
 		url = "http://" + url
 	}
-	return &Client{
-		api: &httpClient{
-			NakamaClient: &pb.NakamaClient{
-				URL:    url,
-				Client: client,
-			},
+	c := &Client{
+		addr:             addr,
+		serverKey:        serverKey,
+		secure:           secure,
+		clock:            systemClock{},
+		serverTimeOffset: &atomic.Int64{},
+	}
+	c.api = &httpClient{
+		NakamaClient: &pb.NakamaClient{
+			URL:         url,
+			Client:      client,
+			Interceptor: c.intercept,
 		},
-		addr:      addr,
-		serverKey: serverKey,
-		secure:    secure,
 	}
+	return c
 }
 
 func (c *httpClient) Close() error {
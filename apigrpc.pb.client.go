@@ -0,0 +1,829 @@
+// generated by protoc-gen-go-session-client. PLEASE DO NOT MODIFY
+
+package nakama_client_go
+
+import (
+	context "context"
+	api "github.com/heroiclabs/nakama-common/api"
+	httputil "github.com/joesonw/nakama-client-go/httputil"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (s *Session) AddFriends(ctx context.Context, req *api.AddFriendsRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.AddFriends(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) AddGroupUsers(ctx context.Context, req *api.AddGroupUsersRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.AddGroupUsers(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) BanGroupUsers(ctx context.Context, req *api.BanGroupUsersRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.BanGroupUsers(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) BlockFriends(ctx context.Context, req *api.BlockFriendsRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.BlockFriends(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) CreateGroup(ctx context.Context, req *api.CreateGroupRequest, opts ...CallOption) (*api.Group, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.CreateGroup(ctx, req, callOpts...)
+}
+
+func (s *Session) DeleteFriends(ctx context.Context, req *api.DeleteFriendsRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.DeleteFriends(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) DeleteGroup(ctx context.Context, req *api.DeleteGroupRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.DeleteGroup(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) DeleteLeaderboardRecord(ctx context.Context, req *api.DeleteLeaderboardRecordRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.DeleteLeaderboardRecord(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) DeleteNotifications(ctx context.Context, req *api.DeleteNotificationsRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.DeleteNotifications(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) DeleteTournamentRecord(ctx context.Context, req *api.DeleteTournamentRecordRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.DeleteTournamentRecord(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) DeleteStorageObjects(ctx context.Context, req *api.DeleteStorageObjectsRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.DeleteStorageObjects(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) EmitEvent(ctx context.Context, req *api.Event, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.Event(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) GetAccount(ctx context.Context, opts ...CallOption) (*api.Account, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.GetAccount(ctx, &emptypb.Empty{}, callOpts...)
+}
+
+func (s *Session) GetUsers(ctx context.Context, req *api.GetUsersRequest, opts ...CallOption) (*api.Users, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.GetUsers(ctx, req, callOpts...)
+}
+
+func (s *Session) GetSubscription(ctx context.Context, req *api.GetSubscriptionRequest, opts ...CallOption) (*api.ValidatedSubscription, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.GetSubscription(ctx, req, callOpts...)
+}
+
+func (s *Session) Healthcheck(ctx context.Context, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.Healthcheck(ctx, &emptypb.Empty{}, callOpts...)
+	return err
+}
+
+func (s *Session) ImportFacebookFriends(ctx context.Context, req *api.ImportFacebookFriendsRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.ImportFacebookFriends(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) ImportSteamFriends(ctx context.Context, req *api.ImportSteamFriendsRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.ImportSteamFriends(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) JoinGroup(ctx context.Context, req *api.JoinGroupRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.JoinGroup(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) JoinTournament(ctx context.Context, req *api.JoinTournamentRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.JoinTournament(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) KickGroupUsers(ctx context.Context, req *api.KickGroupUsersRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.KickGroupUsers(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) LeaveGroup(ctx context.Context, req *api.LeaveGroupRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.LeaveGroup(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) LinkApple(ctx context.Context, req *api.AccountApple, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.LinkApple(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) LinkCustom(ctx context.Context, req *api.AccountCustom, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.LinkCustom(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) LinkDevice(ctx context.Context, req *api.AccountDevice, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.LinkDevice(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) LinkEmail(ctx context.Context, req *api.AccountEmail, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.LinkEmail(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) LinkFacebook(ctx context.Context, req *api.LinkFacebookRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.LinkFacebook(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) LinkFacebookInstantGame(ctx context.Context, req *api.AccountFacebookInstantGame, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.LinkFacebookInstantGame(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) LinkGameCenter(ctx context.Context, req *api.AccountGameCenter, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.LinkGameCenter(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) LinkGoogle(ctx context.Context, req *api.AccountGoogle, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.LinkGoogle(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) LinkSteam(ctx context.Context, req *api.LinkSteamRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.LinkSteam(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) ListChannelMessages(ctx context.Context, req *api.ListChannelMessagesRequest, opts ...CallOption) (*api.ChannelMessageList, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ListChannelMessages(ctx, req, callOpts...)
+}
+
+func (s *Session) ListFriends(ctx context.Context, req *api.ListFriendsRequest, opts ...CallOption) (*api.FriendList, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ListFriends(ctx, req, callOpts...)
+}
+
+func (s *Session) ListGroups(ctx context.Context, req *api.ListGroupsRequest, opts ...CallOption) (*api.GroupList, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ListGroups(ctx, req, callOpts...)
+}
+
+func (s *Session) ListGroupUsers(ctx context.Context, req *api.ListGroupUsersRequest, opts ...CallOption) (*api.GroupUserList, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ListGroupUsers(ctx, req, callOpts...)
+}
+
+func (s *Session) ListLeaderboardRecords(ctx context.Context, req *api.ListLeaderboardRecordsRequest, opts ...CallOption) (*api.LeaderboardRecordList, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ListLeaderboardRecords(ctx, req, callOpts...)
+}
+
+func (s *Session) ListLeaderboardRecordsAroundOwner(ctx context.Context, req *api.ListLeaderboardRecordsAroundOwnerRequest, opts ...CallOption) (*api.LeaderboardRecordList, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ListLeaderboardRecordsAroundOwner(ctx, req, callOpts...)
+}
+
+func (s *Session) ListMatches(ctx context.Context, req *api.ListMatchesRequest, opts ...CallOption) (*api.MatchList, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ListMatches(ctx, req, callOpts...)
+}
+
+func (s *Session) ListNotifications(ctx context.Context, req *api.ListNotificationsRequest, opts ...CallOption) (*api.NotificationList, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ListNotifications(ctx, req, callOpts...)
+}
+
+func (s *Session) ListStorageObjects(ctx context.Context, req *api.ListStorageObjectsRequest, opts ...CallOption) (*api.StorageObjectList, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ListStorageObjects(ctx, req, callOpts...)
+}
+
+func (s *Session) ListSubscriptions(ctx context.Context, req *api.ListSubscriptionsRequest, opts ...CallOption) (*api.SubscriptionList, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ListSubscriptions(ctx, req, callOpts...)
+}
+
+func (s *Session) ListTournaments(ctx context.Context, req *api.ListTournamentsRequest, opts ...CallOption) (*api.TournamentList, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ListTournaments(ctx, req, callOpts...)
+}
+
+func (s *Session) ListTournamentRecords(ctx context.Context, req *api.ListTournamentRecordsRequest, opts ...CallOption) (*api.TournamentRecordList, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ListTournamentRecords(ctx, req, callOpts...)
+}
+
+func (s *Session) ListTournamentRecordsAroundOwner(ctx context.Context, req *api.ListTournamentRecordsAroundOwnerRequest, opts ...CallOption) (*api.TournamentRecordList, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ListTournamentRecordsAroundOwner(ctx, req, callOpts...)
+}
+
+func (s *Session) ListUserGroups(ctx context.Context, req *api.ListUserGroupsRequest, opts ...CallOption) (*api.UserGroupList, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ListUserGroups(ctx, req, callOpts...)
+}
+
+func (s *Session) PromoteGroupUsers(ctx context.Context, req *api.PromoteGroupUsersRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.PromoteGroupUsers(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) DemoteGroupUsers(ctx context.Context, req *api.DemoteGroupUsersRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.DemoteGroupUsers(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) ReadStorageObjects(ctx context.Context, req *api.ReadStorageObjectsRequest, opts ...CallOption) (*api.StorageObjects, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ReadStorageObjects(ctx, req, callOpts...)
+}
+
+func (s *Session) UnlinkApple(ctx context.Context, req *api.AccountApple, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.UnlinkApple(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) UnlinkCustom(ctx context.Context, req *api.AccountCustom, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.UnlinkCustom(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) UnlinkDevice(ctx context.Context, req *api.AccountDevice, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.UnlinkDevice(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) UnlinkEmail(ctx context.Context, req *api.AccountEmail, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.UnlinkEmail(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) UnlinkFacebook(ctx context.Context, req *api.AccountFacebook, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.UnlinkFacebook(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) UnlinkFacebookInstantGame(ctx context.Context, req *api.AccountFacebookInstantGame, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.UnlinkFacebookInstantGame(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) UnlinkGameCenter(ctx context.Context, req *api.AccountGameCenter, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.UnlinkGameCenter(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) UnlinkGoogle(ctx context.Context, req *api.AccountGoogle, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.UnlinkGoogle(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) UnlinkSteam(ctx context.Context, req *api.AccountSteam, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.UnlinkSteam(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) UpdateAccount(ctx context.Context, req *api.UpdateAccountRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.UpdateAccount(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) UpdateGroup(ctx context.Context, req *api.UpdateGroupRequest, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.api.UpdateGroup(ctx, req, callOpts...)
+	return err
+}
+
+func (s *Session) ValidatePurchaseApple(ctx context.Context, req *api.ValidatePurchaseAppleRequest, opts ...CallOption) (*api.ValidatePurchaseResponse, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ValidatePurchaseApple(ctx, req, callOpts...)
+}
+
+func (s *Session) ValidateSubscriptionApple(ctx context.Context, req *api.ValidateSubscriptionAppleRequest, opts ...CallOption) (*api.ValidateSubscriptionResponse, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ValidateSubscriptionApple(ctx, req, callOpts...)
+}
+
+func (s *Session) ValidatePurchaseGoogle(ctx context.Context, req *api.ValidatePurchaseGoogleRequest, opts ...CallOption) (*api.ValidatePurchaseResponse, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ValidatePurchaseGoogle(ctx, req, callOpts...)
+}
+
+func (s *Session) ValidateSubscriptionGoogle(ctx context.Context, req *api.ValidateSubscriptionGoogleRequest, opts ...CallOption) (*api.ValidateSubscriptionResponse, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ValidateSubscriptionGoogle(ctx, req, callOpts...)
+}
+
+func (s *Session) ValidatePurchaseHuawei(ctx context.Context, req *api.ValidatePurchaseHuaweiRequest, opts ...CallOption) (*api.ValidatePurchaseResponse, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ValidatePurchaseHuawei(ctx, req, callOpts...)
+}
+
+func (s *Session) ValidatePurchaseFacebookInstant(ctx context.Context, req *api.ValidatePurchaseFacebookInstantRequest, opts ...CallOption) (*api.ValidatePurchaseResponse, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.ValidatePurchaseFacebookInstant(ctx, req, callOpts...)
+}
+
+func (s *Session) WriteLeaderboardRecord(ctx context.Context, req *api.WriteLeaderboardRecordRequest, opts ...CallOption) (*api.LeaderboardRecord, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.WriteLeaderboardRecord(ctx, req, callOpts...)
+}
+
+func (s *Session) WriteStorageObjects(ctx context.Context, req *api.WriteStorageObjectsRequest, opts ...CallOption) (*api.StorageObjectAcks, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.WriteStorageObjects(ctx, req, callOpts...)
+}
+
+func (s *Session) WriteTournamentRecord(ctx context.Context, req *api.WriteTournamentRecordRequest, opts ...CallOption) (*api.LeaderboardRecord, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx, err := s.attachSessionContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.api.WriteTournamentRecord(ctx, req, callOpts...)
+}
+
+func (s *Client) authenticateApple(ctx context.Context, req *api.AuthenticateAppleRequest, opts ...CallOption) (*Session, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx = httputil.WithBasicAuth(ctx, s.serverKey, "")
+	res, err := s.api.AuthenticateApple(ctx, req, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.newAuthenticatedSession(ctx, res)
+}
+
+func (s *Client) authenticateCustom(ctx context.Context, req *api.AuthenticateCustomRequest, opts ...CallOption) (*Session, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx = httputil.WithBasicAuth(ctx, s.serverKey, "")
+	res, err := s.api.AuthenticateCustom(ctx, req, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.newAuthenticatedSession(ctx, res)
+}
+
+func (s *Client) authenticateDevice(ctx context.Context, req *api.AuthenticateDeviceRequest, opts ...CallOption) (*Session, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx = httputil.WithBasicAuth(ctx, s.serverKey, "")
+	res, err := s.api.AuthenticateDevice(ctx, req, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.newAuthenticatedSession(ctx, res)
+}
+
+func (s *Client) authenticateEmail(ctx context.Context, req *api.AuthenticateEmailRequest, opts ...CallOption) (*Session, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx = httputil.WithBasicAuth(ctx, s.serverKey, "")
+	res, err := s.api.AuthenticateEmail(ctx, req, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.newAuthenticatedSession(ctx, res)
+}
+
+func (s *Client) authenticateFacebook(ctx context.Context, req *api.AuthenticateFacebookRequest, opts ...CallOption) (*Session, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx = httputil.WithBasicAuth(ctx, s.serverKey, "")
+	res, err := s.api.AuthenticateFacebook(ctx, req, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.newAuthenticatedSession(ctx, res)
+}
+
+func (s *Client) authenticateFacebookInstantGame(ctx context.Context, req *api.AuthenticateFacebookInstantGameRequest, opts ...CallOption) (*Session, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx = httputil.WithBasicAuth(ctx, s.serverKey, "")
+	res, err := s.api.AuthenticateFacebookInstantGame(ctx, req, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.newAuthenticatedSession(ctx, res)
+}
+
+func (s *Client) authenticateGameCenter(ctx context.Context, req *api.AuthenticateGameCenterRequest, opts ...CallOption) (*Session, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx = httputil.WithBasicAuth(ctx, s.serverKey, "")
+	res, err := s.api.AuthenticateGameCenter(ctx, req, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.newAuthenticatedSession(ctx, res)
+}
+
+func (s *Client) authenticateGoogle(ctx context.Context, req *api.AuthenticateGoogleRequest, opts ...CallOption) (*Session, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx = httputil.WithBasicAuth(ctx, s.serverKey, "")
+	res, err := s.api.AuthenticateGoogle(ctx, req, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.newAuthenticatedSession(ctx, res)
+}
+
+func (s *Client) authenticateSteam(ctx context.Context, req *api.AuthenticateSteamRequest, opts ...CallOption) (*Session, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx = httputil.WithBasicAuth(ctx, s.serverKey, "")
+	res, err := s.api.AuthenticateSteam(ctx, req, callOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.newAuthenticatedSession(ctx, res)
+}
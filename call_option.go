@@ -0,0 +1,83 @@
+package nakama_client_go
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// CallOption customizes a single API call, on both the gRPC and HTTP transports.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	grpcOptions []grpc.CallOption
+	timeout     time.Duration
+	retryPolicy *RetryPolicy
+	header      metadata.MD
+}
+
+// CallGRPCOptions passes options to the gRPC invocation, the HTTP transport honours grpc.Header,
+// grpc.Trailer and grpc.PerRPCCredentials.
+func CallGRPCOptions(opts ...grpc.CallOption) CallOption {
+	return func(o *callOptions) {
+		o.grpcOptions = append(o.grpcOptions, opts...)
+	}
+}
+
+// CallTimeout bounds the call, including retries and session refreshes.
+func CallTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// CallRetryPolicy overrides the client retry policy for the call, methods with side effects are
+// only retried if they are listed in RetryPolicy.Methods.
+func CallRetryPolicy(policy RetryPolicy) CallOption {
+	return func(o *callOptions) {
+		o.retryPolicy = &policy
+	}
+}
+
+func CallWithoutRetry() CallOption {
+	return CallRetryPolicy(RetryPolicy{MaxAttempts: 1})
+}
+
+// CallHeader sends an extra request header, as gRPC metadata or an HTTP header.
+func CallHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = metadata.MD{}
+		}
+		o.header.Append(key, value)
+	}
+}
+
+// CallResponseHeader stores the response headers in md once the call returns.
+func CallResponseHeader(md *metadata.MD) CallOption {
+	return CallGRPCOptions(grpc.Header(md))
+}
+
+// applyCallOptions returns the context for the call and the gRPC options to invoke it with, the
+// cancel function must be called when the call returns.
+func applyCallOptions(ctx context.Context, opts ...CallOption) (context.Context, context.CancelFunc, []grpc.CallOption) {
+	o := &callOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	cancel := context.CancelFunc(func() {})
+	if o.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+	}
+	if o.retryPolicy != nil {
+		ctx = WithCallRetryPolicy(ctx, *o.retryPolicy)
+	}
+	if len(o.header) > 0 {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		ctx = metadata.NewOutgoingContext(ctx, metadata.Join(md, o.header))
+	}
+	return ctx, cancel, o.grpcOptions
+}
@@ -2,18 +2,32 @@ package nakama_client_go
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/heroiclabs/nakama-common/api"
+	"google.golang.org/grpc"
 
 	"github.com/joesonw/nakama-client-go/httputil"
 )
 
 type Client struct {
-	api       apiClient
-	serverKey string
-	addr      string
-	secure    bool
+	api          apiClient
+	serverKey    string
+	addr         string
+	secure       bool
+	interceptors []grpc.UnaryClientInterceptor
+	retryPolicy  *RetryPolicy
+
+	sessionStore           SessionStore
+	sessionProfile         string
+	onSessionSaveFailed    func(sess *Session, err error)
+	sessionVerificationKey []byte
+
+	clock            Clock
+	serverTimeOffset *atomic.Int64
 }
 
 func (c *Client) Close() error {
@@ -21,9 +35,10 @@ func (c *Client) Close() error {
 }
 
 type AuthenticateOption struct {
-	Create   *bool
-	Vars     map[string]string
-	Username string
+	Create      *bool
+	Vars        map[string]string
+	Username    string
+	CallOptions []CallOption
 }
 
 func parseAuthenticateOptions(opts ...AuthenticateOption) AuthenticateOption {
@@ -36,62 +51,43 @@ func parseAuthenticateOptions(opts ...AuthenticateOption) AuthenticateOption {
 
 func (c *Client) AuthenticateApple(ctx context.Context, token string, opts ...AuthenticateOption) (*Session, error) {
 	opt := parseAuthenticateOptions(opts...)
-
-	res, err := c.api.AuthenticateApple(httputil.WithBasicAuth(ctx, c.serverKey, ""), &api.AuthenticateAppleRequest{
+	return c.authenticateApple(ctx, &api.AuthenticateAppleRequest{
 		Account: &api.AccountApple{
 			Token: token,
 			Vars:  opt.Vars,
 		},
 		Create:   boolValue(opt.Create),
 		Username: opt.Username,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return newSession(res, c)
+	}, opt.CallOptions...)
 }
 
 func (c *Client) AuthenticateCustom(ctx context.Context, id string, opts ...AuthenticateOption) (*Session, error) {
 	opt := parseAuthenticateOptions(opts...)
-
-	res, err := c.api.AuthenticateCustom(httputil.WithBasicAuth(ctx, c.serverKey, ""), &api.AuthenticateCustomRequest{
+	return c.authenticateCustom(ctx, &api.AuthenticateCustomRequest{
 		Account: &api.AccountCustom{
 			Id:   id,
 			Vars: opt.Vars,
 		},
 		Create:   boolValue(opt.Create),
 		Username: opt.Username,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return newSession(res, c)
+	}, opt.CallOptions...)
 }
 
 func (c *Client) AuthenticateDevice(ctx context.Context, id string, opts ...AuthenticateOption) (*Session, error) {
 	opt := parseAuthenticateOptions(opts...)
-
-	res, err := c.api.AuthenticateDevice(httputil.WithBasicAuth(ctx, c.serverKey, ""), &api.AuthenticateDeviceRequest{
+	return c.authenticateDevice(ctx, &api.AuthenticateDeviceRequest{
 		Account: &api.AccountDevice{
 			Id:   id,
 			Vars: opt.Vars,
 		},
 		Create:   boolValue(opt.Create),
 		Username: opt.Username,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return newSession(res, c)
+	}, opt.CallOptions...)
 }
 
 func (c *Client) AuthenticateEmail(ctx context.Context, email, password string, opts ...AuthenticateOption) (*Session, error) {
 	opt := parseAuthenticateOptions(opts...)
-
-	res, err := c.api.AuthenticateEmail(httputil.WithBasicAuth(ctx, c.serverKey, ""), &api.AuthenticateEmailRequest{
+	return c.authenticateEmail(ctx, &api.AuthenticateEmailRequest{
 		Account: &api.AccountEmail{
 			Email:    email,
 			Password: password,
@@ -99,54 +95,36 @@ func (c *Client) AuthenticateEmail(ctx context.Context, email, password string,
 		},
 		Create:   boolValue(opt.Create),
 		Username: opt.Username,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return newSession(res, c)
+	}, opt.CallOptions...)
 }
 
 func (c *Client) AuthenticateFacebook(ctx context.Context, token string, opts ...AuthenticateOption) (*Session, error) {
 	opt := parseAuthenticateOptions(opts...)
-
-	res, err := c.api.AuthenticateFacebook(httputil.WithBasicAuth(ctx, c.serverKey, ""), &api.AuthenticateFacebookRequest{
+	return c.authenticateFacebook(ctx, &api.AuthenticateFacebookRequest{
 		Account: &api.AccountFacebook{
 			Token: token,
 			Vars:  opt.Vars,
 		},
 		Create:   boolValue(opt.Create),
 		Username: opt.Username,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return newSession(res, c)
+	}, opt.CallOptions...)
 }
 
 func (c *Client) AuthenticateFacebookInstantGame(ctx context.Context, signedPlayerInfo string, opts ...AuthenticateOption) (*Session, error) {
 	opt := parseAuthenticateOptions(opts...)
-
-	res, err := c.api.AuthenticateFacebookInstantGame(httputil.WithBasicAuth(ctx, c.serverKey, ""), &api.AuthenticateFacebookInstantGameRequest{
+	return c.authenticateFacebookInstantGame(ctx, &api.AuthenticateFacebookInstantGameRequest{
 		Account: &api.AccountFacebookInstantGame{
 			SignedPlayerInfo: signedPlayerInfo,
 			Vars:             opt.Vars,
 		},
 		Create:   boolValue(opt.Create),
 		Username: opt.Username,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return newSession(res, c)
+	}, opt.CallOptions...)
 }
 
 func (c *Client) AuthenticateGameCenter(ctx context.Context, bundleId, playerId, publicKeyUrl, salt, signature string, timestamp time.Time, opts ...AuthenticateOption) (*Session, error) {
 	opt := parseAuthenticateOptions(opts...)
-
-	res, err := c.api.AuthenticateGameCenter(httputil.WithBasicAuth(ctx, c.serverKey, ""), &api.AuthenticateGameCenterRequest{
+	return c.authenticateGameCenter(ctx, &api.AuthenticateGameCenterRequest{
 		Account: &api.AccountGameCenter{
 			BundleId:         bundleId,
 			PlayerId:         playerId,
@@ -158,53 +136,93 @@ func (c *Client) AuthenticateGameCenter(ctx context.Context, bundleId, playerId,
 		},
 		Create:   boolValue(opt.Create),
 		Username: opt.Username,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return newSession(res, c)
+	}, opt.CallOptions...)
 }
 
 func (c *Client) AuthenticateGoogle(ctx context.Context, token string, opts ...AuthenticateOption) (*Session, error) {
 	opt := parseAuthenticateOptions(opts...)
-
-	res, err := c.api.AuthenticateGoogle(httputil.WithBasicAuth(ctx, c.serverKey, ""), &api.AuthenticateGoogleRequest{
+	return c.authenticateGoogle(ctx, &api.AuthenticateGoogleRequest{
 		Account: &api.AccountGoogle{
 			Token: token,
 			Vars:  opt.Vars,
 		},
 		Create:   boolValue(opt.Create),
 		Username: opt.Username,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return newSession(res, c)
+	}, opt.CallOptions...)
 }
 
 func (c *Client) AuthenticateSteam(ctx context.Context, token string, opts ...AuthenticateOption) (*Session, error) {
 	opt := parseAuthenticateOptions(opts...)
-
-	res, err := c.api.AuthenticateSteam(httputil.WithBasicAuth(ctx, c.serverKey, ""), &api.AuthenticateSteamRequest{
+	return c.authenticateSteam(ctx, &api.AuthenticateSteamRequest{
 		Account: &api.AccountSteam{
 			Token: token,
 			Vars:  opt.Vars,
 		},
 		Create:   boolValue(opt.Create),
 		Username: opt.Username,
-	})
+	}, opt.CallOptions...)
+}
+
+func (c *Client) newAuthenticatedSession(ctx context.Context, res *api.Session) (*Session, error) {
+	sess, err := newSession(res, c)
 	if err != nil {
 		return nil, err
 	}
+	// Nakama tokens may carry no iat, the Date header is the estimate then
+	if iat := sess.claims.IssuedAt; iat != 0 {
+		c.observeServerTime(time.Unix(iat, 0))
+	}
+	sess.save(ctx)
+	return sess, nil
+}
+
+// WithSessionVerificationKey makes sessions verify the HS256 signature of their token against
+// the server session.encryption_key, for services that accept tokens forwarded by players.
+// RestoreSession then also rejects expired tokens, as they are no longer a trusted identity.
+func (c *Client) WithSessionVerificationKey(key string) *Client {
+	c.sessionVerificationKey = []byte(key)
+	return c
+}
+
+// RestoreSession resumes a session from persisted tokens without authenticating again. The
+// refresh token may be empty, in which case the session ends when the token expires.
+func (c *Client) RestoreSession(token, refreshToken string) (*Session, error) {
+	return c.RestoreSessionData(SessionData{
+		Token:        token,
+		RefreshToken: refreshToken,
+	})
+}
 
-	return newSession(res, c)
+func (c *Client) RestoreSessionData(data SessionData) (*Session, error) {
+	sess, err := newSession(&api.Session{
+		Token:        data.Token,
+		RefreshToken: data.RefreshToken,
+		Created:      data.Created,
+	}, c)
+	if errors.Is(err, ErrInvalidToken) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if sess.UserID() == "" {
+		return nil, fmt.Errorf("%w: missing user id", ErrInvalidToken)
+	}
+	if sess.IsRefreshExpired() || (c.sessionVerificationKey != nil && sess.IsExpired()) {
+		return nil, fmt.Errorf("restore session: %w", ErrSessionExpired)
+	}
+	return sess, nil
 }
 
-func (c *Client) RPC(ctx context.Context, httpKey, id string, payload []byte) (*api.Rpc, error) {
+// RPC calls a runtime function with the http_key.
+//
+// Deprecated: use NewServerClient, which is configured with the key once.
+func (c *Client) RPC(ctx context.Context, httpKey, id string, payload []byte, opts ...CallOption) (*api.Rpc, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
 	return c.api.RpcFunc2(httputil.WithHTTPKeyAuth(ctx, httpKey), &api.Rpc{
 		Id:      id,
 		Payload: string(payload),
-	})
+		HttpKey: httpKey,
+	}, callOpts...)
 }
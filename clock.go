@@ -0,0 +1,57 @@
+package nakama_client_go
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const headerDate = "date"
+
+// Clock is the source of local time, replaceable in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (c *Client) WithClock(clock Clock) *Client {
+	c.clock = clock
+	return c
+}
+
+// ServerTimeOffset is the estimated difference between the server and the local clock, learned
+// from token issue times and HTTP Date headers.
+func (c *Client) ServerTimeOffset() time.Duration {
+	return time.Duration(c.serverTimeOffset.Load())
+}
+
+// now is the estimated server time, used for every expiry decision.
+func (c *Client) now() time.Time {
+	return c.clock.Now().Add(c.ServerTimeOffset())
+}
+
+func (c *Client) observeServerTime(serverTime time.Time) {
+	if serverTime.IsZero() {
+		return
+	}
+	c.serverTimeOffset.Store(int64(serverTime.Sub(c.clock.Now())))
+}
+
+func (c *Client) clockInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	var header metadata.MD
+	err := invoker(ctx, method, req, reply, cc, append(append([]grpc.CallOption{}, opts...), grpc.Header(&header))...)
+	if dates := header.Get(headerDate); len(dates) > 0 {
+		if t, parseErr := http.ParseTime(dates[0]); parseErr == nil {
+			c.observeServerTime(t)
+		}
+	}
+	return err
+}
@@ -0,0 +1,100 @@
+package nakama_client_go
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/heroiclabs/nakama-common/rtapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Sentinel errors, matched with errors.Is against errors returned by any transport.
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrAlreadyExists     = errors.New("already exists")
+	ErrInvalidArgument   = errors.New("invalid argument")
+	ErrUnauthenticated   = errors.New("unauthenticated")
+	ErrPermissionDenied  = errors.New("permission denied")
+	ErrUnavailable       = errors.New("unavailable")
+	ErrVersionConflict   = errors.New("version conflict")
+	ErrSessionExpired    = errors.New("session expired")
+	ErrInvalidToken      = errors.New("invalid token")
+	ErrSocketClosed      = errors.New("socket closed")
+	ErrMatchJoinRejected = errors.New("match join rejected")
+)
+
+var statusCodeErrors = map[codes.Code]error{
+	codes.NotFound:         ErrNotFound,
+	codes.AlreadyExists:    ErrAlreadyExists,
+	codes.InvalidArgument:  ErrInvalidArgument,
+	codes.Unauthenticated:  ErrUnauthenticated,
+	codes.PermissionDenied: ErrPermissionDenied,
+	codes.Unavailable:      ErrUnavailable,
+}
+
+var realtimeCodeErrors = map[rtapi.Error_Code]error{
+	rtapi.Error_BAD_INPUT:                  ErrInvalidArgument,
+	rtapi.Error_MATCH_NOT_FOUND:            ErrNotFound,
+	rtapi.Error_MATCH_JOIN_REJECTED:        ErrMatchJoinRejected,
+	rtapi.Error_RUNTIME_FUNCTION_NOT_FOUND: ErrNotFound,
+	rtapi.Error_UNRECOGNIZED_PAYLOAD:       ErrInvalidArgument,
+	rtapi.Error_MISSING_PAYLOAD:            ErrInvalidArgument,
+}
+
+// StatusError is returned by every API call that failed on the server, it keeps the gRPC status
+// (so status.Code works) and matches the sentinel errors with errors.Is.
+type StatusError struct {
+	err error
+}
+
+func (e *StatusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.err
+}
+
+func (e *StatusError) GRPCStatus() *status.Status {
+	s, _ := status.FromError(e.err)
+	return s
+}
+
+func (e *StatusError) Code() codes.Code {
+	return status.Code(e.err)
+}
+
+func (e *StatusError) Is(target error) bool {
+	s := e.GRPCStatus()
+	if target == ErrVersionConflict {
+		// nakama does not tell version and permission rejections apart
+		return s.Code() == codes.InvalidArgument && isStorageRejectedMessage(s.Message())
+	}
+	sentinel, ok := statusCodeErrors[s.Code()]
+	return ok && sentinel == target
+}
+
+func isStorageRejectedMessage(msg string) bool {
+	return strings.Contains(msg, "Storage write rejected") || strings.Contains(msg, "Storage delete rejected")
+}
+
+func wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var se *StatusError
+	if errors.As(err, &se) {
+		return err
+	}
+	if _, ok := status.FromError(err); !ok {
+		return err
+	}
+	return &StatusError{err: err}
+}
+
+func errorInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return wrapError(invoker(ctx, method, req, reply, cc, opts...))
+}
@@ -21,19 +21,19 @@ type (
 
 func WithBasicAuth(ctx context.Context, username, password string) context.Context {
 	ctx = context.WithValue(ctx, contextKeyBasicAuth{}, &contextBasicAuth{username: username, password: password})
-	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+	ctx = setOutgoingMetadata(ctx, "authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
 	return ctx
 }
 
 func WithBearerJWT(ctx context.Context, token string) context.Context {
 	ctx = context.WithValue(ctx, contextKeyBearerJWT{}, token)
-	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	ctx = setOutgoingMetadata(ctx, "authorization", "Bearer "+token)
 	return ctx
 }
 
 func WithHTTPKeyAuth(ctx context.Context, key string) context.Context {
 	ctx = context.WithValue(ctx, contextKeyHTTPKeyAuth{}, key)
-	ctx = metadata.AppendToOutgoingContext(ctx, "q_http_key", key)
+	ctx = setOutgoingMetadata(ctx, "q_http_key", key)
 	return ctx
 }
 
@@ -42,6 +42,15 @@ func WithSession(ctx context.Context, sess *api.Session) context.Context {
 	return ctx
 }
 
+// setOutgoingMetadata replaces the key instead of appending to it, so credentials can be swapped
+// on an existing context.
+func setOutgoingMetadata(ctx context.Context, key, value string) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md.Set(key, value)
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
 func GetBasicAuth(ctx context.Context) (username, password string) {
 	auth, ok := ctx.Value(contextKeyBasicAuth{}).(*contextBasicAuth)
 	if !ok {
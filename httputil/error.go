@@ -2,13 +2,114 @@ package httputil
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
+const (
+	headerGRPCStatus  = "Grpc-Status"
+	headerGRPCMessage = "Grpc-Message"
+
+	maxErrorBodySize = 1 << 20
+)
+
+var errorBodyUnmarshaler = protojson.UnmarshalOptions{
+	DiscardUnknown: true,
+}
+
+// CheckResponse turns a failed response into a gRPC status error, so that status.Code and
+// status.FromError behave the same regardless of the transport.
 func CheckResponse(ctx context.Context, res *http.Response) error {
-	if res.StatusCode >= http.StatusBadRequest {
-		return fmt.Errorf("http error %d: %s", res.StatusCode, res.Status)
+	if res.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+	return StatusFromResponse(res).Err()
+}
+
+// StatusFromResponse reads the error body (and grpc-status/grpc-message headers) of a failed response.
+func StatusFromResponse(res *http.Response) *status.Status {
+	s := &spb.Status{}
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+	if err == nil && len(body) > 0 {
+		if err := errorBodyUnmarshaler.Unmarshal(body, s); err != nil {
+			// details may reference types unknown to this binary, fall back to code and message only
+			var fallback struct {
+				Code    int32  `json:"code"`
+				Message string `json:"message"`
+			}
+			s = &spb.Status{}
+			if json.Unmarshal(body, &fallback) == nil {
+				s.Code = fallback.Code
+				s.Message = fallback.Message
+			}
+		}
+	}
+
+	if v := res.Header.Get(headerGRPCStatus); v != "" {
+		if code, err := strconv.ParseInt(v, 10, 32); err == nil {
+			s.Code = int32(code)
+		}
+	}
+	if v := res.Header.Get(headerGRPCMessage); v != "" {
+		if msg, err := url.PathUnescape(v); err == nil {
+			s.Message = msg
+		} else {
+			s.Message = v
+		}
+	}
+
+	if s.Code == int32(codes.OK) {
+		s.Code = int32(CodeFromHTTPStatus(res.StatusCode))
+	}
+	if s.Message == "" {
+		s.Message = res.Status
+	}
+
+	return status.FromProto(s)
+}
+
+// CodeFromHTTPStatus is the inverse of grpc-gateway's HTTPStatusFromCode.
+//
+//nolint:gocyclo
+func CodeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusOK:
+		return codes.OK
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusRequestedRangeNotSatisfiable:
+		return codes.OutOfRange
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case 499: //nolint:gomnd // client closed request
+		return codes.Canceled
+	case http.StatusInternalServerError:
+		return codes.Internal
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
 	}
-	return nil
+	return codes.Unknown
 }
@@ -2,36 +2,152 @@ package httputil
 
 import (
 	"context"
+	"encoding/base64"
 	"net/http"
+	"strings"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
-func AttachGRPCToRequest(ctx context.Context, req *http.Request, opts ...grpc.CallOption) *http.Request {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		for k, vv := range md {
-			for _, v := range vv {
-				req.Header.Add(k, v)
+const (
+	metadataHeaderPrefix = "Grpc-Metadata-"
+	trailerHeaderPrefix  = "Grpc-Trailer-"
+	queryMetadataPrefix  = "q_"
+	binaryMetadataSuffix = "-bin"
+)
+
+// headers grpc-gateway passes through to the server without the Grpc-Metadata- prefix
+var permanentHTTPHeaders = map[string]bool{
+	"accept":                true,
+	"accept-charset":        true,
+	"accept-language":       true,
+	"accept-ranges":         true,
+	"authorization":         true,
+	"cache-control":         true,
+	"content-type":          true,
+	"cookie":                true,
+	"date":                  true,
+	"expect":                true,
+	"from":                  true,
+	"host":                  true,
+	"if-match":              true,
+	"if-modified-since":     true,
+	"if-none-match":         true,
+	"if-schedule-tag-match": true,
+	"if-unmodified-since":   true,
+	"max-forwards":          true,
+	"origin":                true,
+	"pragma":                true,
+	"referer":               true,
+	"user-agent":            true,
+	"via":                   true,
+	"warning":               true,
+}
+
+// AttachGRPCToRequest copies outgoing metadata and per-RPC credentials onto the request, the same
+// way grpc-gateway maps them back on the server side: "q_" prefixed keys become query parameters,
+// permanent HTTP headers are sent as is and everything else gets the Grpc-Metadata- prefix.
+func AttachGRPCToRequest(ctx context.Context, req *http.Request, opts ...grpc.CallOption) (*http.Request, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+
+	for _, opt := range opts {
+		switch o := opt.(type) { //nolint:gocritic
+		case grpc.PerRPCCredsCallOption:
+			if o.Creds.RequireTransportSecurity() && req.URL.Scheme != "https" {
+				return nil, status.Error(codes.Unauthenticated, "per-RPC credentials require transport security")
+			}
+			credsMD, err := o.Creds.GetRequestMetadata(ctx, req.URL.String())
+			if err != nil {
+				return nil, status.Error(codes.Unauthenticated, err.Error())
+			}
+			for k, v := range credsMD {
+				md.Set(k, v)
+			}
+		}
+	}
+
+	query := req.URL.Query()
+	queryChanged := false
+	for k, vv := range md {
+		if strings.HasPrefix(k, queryMetadataPrefix) {
+			name := strings.TrimPrefix(k, queryMetadataPrefix)
+			if !query.Has(name) {
+				query[name] = vv
+				queryChanged = true
+			}
+			continue
+		}
+
+		header := k
+		if !permanentHTTPHeaders[k] {
+			header = metadataHeaderPrefix + k
+		}
+		req.Header.Del(header)
+		for _, v := range vv {
+			if strings.HasSuffix(k, binaryMetadataSuffix) {
+				v = base64.StdEncoding.EncodeToString([]byte(v))
 			}
+			req.Header.Add(header, v)
 		}
 	}
+	if queryChanged {
+		req.URL.RawQuery = query.Encode()
+	}
 
-	return req
+	return req, nil
 }
 
+// RetrieveGRPCFromResponse fills grpc.Header and grpc.Trailer call options from the response. It
+// should be called after the body is consumed, otherwise HTTP trailers are not available yet.
 func RetrieveGRPCFromResponse(ctx context.Context, res *http.Response, opts ...grpc.CallOption) *http.Response {
 	for _, opt := range opts {
-		switch o := opt.(type) { //nolint:gocritic
+		switch o := opt.(type) {
 		case grpc.HeaderCallOption:
-			md := metadata.MD{}
-			for k, vv := range res.Header {
-				md[k] = append(md[k], vv...)
-			}
-			*o.HeaderAddr = md
+			*o.HeaderAddr = headerMetadata(res)
+		case grpc.TrailerCallOption:
+			*o.TrailerAddr = trailerMetadata(res)
 		}
 	}
 
 	return res
 }
+
+func headerMetadata(res *http.Response) metadata.MD {
+	md := metadata.MD{}
+	for k, vv := range res.Header {
+		if strings.HasPrefix(k, trailerHeaderPrefix) {
+			continue
+		}
+		appendMetadata(md, strings.TrimPrefix(k, metadataHeaderPrefix), vv)
+	}
+	return md
+}
+
+func trailerMetadata(res *http.Response) metadata.MD {
+	md := metadata.MD{}
+	for k, vv := range res.Header {
+		if strings.HasPrefix(k, trailerHeaderPrefix) {
+			appendMetadata(md, strings.TrimPrefix(k, trailerHeaderPrefix), vv)
+		}
+	}
+	for k, vv := range res.Trailer {
+		appendMetadata(md, strings.TrimPrefix(k, trailerHeaderPrefix), vv)
+	}
+	return md
+}
+
+func appendMetadata(md metadata.MD, key string, vv []string) {
+	key = strings.ToLower(key)
+	for _, v := range vv {
+		if strings.HasSuffix(key, binaryMetadataSuffix) {
+			if b, err := base64.StdEncoding.DecodeString(v); err == nil {
+				v = string(b)
+			}
+		}
+		md.Append(key, v)
+	}
+}
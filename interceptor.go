@@ -0,0 +1,30 @@
+package nakama_client_go
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// WithInterceptors registers unary interceptors that wrap every API call made by the client and
+// its sessions, regardless of the transport. The first interceptor is the outermost one. It is
+// not safe to call while requests are in flight.
+func (c *Client) WithInterceptors(interceptors ...grpc.UnaryClientInterceptor) *Client {
+	c.interceptors = append(c.interceptors, interceptors...)
+	return c
+}
+
+func (c *Client) intercept(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	interceptors := append(append([]grpc.UnaryClientInterceptor{}, c.interceptors...), sessionInterceptor, c.retryInterceptor, c.clockInterceptor, errorInterceptor)
+	return chainUnaryInterceptors(interceptors, invoker)(ctx, method, req, reply, cc, opts...)
+}
+
+func chainUnaryInterceptors(interceptors []grpc.UnaryClientInterceptor, invoker grpc.UnaryInvoker) grpc.UnaryInvoker {
+	if len(interceptors) == 0 {
+		return invoker
+	}
+	next := chainUnaryInterceptors(interceptors[1:], invoker)
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return interceptors[0](ctx, method, req, reply, cc, next, opts...)
+	}
+}
@@ -19,16 +19,35 @@ import (
 )
 
 type NakamaClient struct {
-	Client *http.Client
-	URL    string
+	Client      *http.Client
+	URL         string
+	Interceptor grpc.UnaryClientInterceptor
+}
+
+func (c *NakamaClient) invoke(ctx context.Context, method string, req, res interface{}, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if c.Interceptor == nil {
+		return invoker(ctx, method, req, res, nil, opts...)
+	}
+	return c.Interceptor(ctx, method, req, res, nil, invoker, opts...)
 }
 
 func (c *NakamaClient) AddFriends(ctx context.Context, req *api.AddFriendsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	res := &emptypb.Empty{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doAddFriends(ctx, req.(*api.AddFriendsRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/AddFriends", req, res, invoker, opts...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *NakamaClient) doAddFriends(ctx context.Context, req *api.AddFriendsRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/friend"
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	for _, v := range req.Ids {
@@ -40,41 +59,50 @@ func (c *NakamaClient) AddFriends(ctx context.Context, req *api.AddFriendsReques
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) AddGroupUsers(ctx context.Context, req *api.AddGroupUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doAddGroupUsers(ctx, req.(*api.AddGroupUsersRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/AddGroupUsers", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) AddGroupUsers(ctx context.Context, req *api.AddGroupUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doAddGroupUsers(ctx context.Context, req *api.AddGroupUsersRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/group/%v/add", req.GroupId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("group_id", req.GroupId)
@@ -84,137 +112,164 @@ func (c *NakamaClient) AddGroupUsers(ctx context.Context, req *api.AddGroupUsers
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) SessionRefresh(ctx context.Context, req *api.SessionRefreshRequest, opts ...grpc.CallOption) (*api.Session, error) {
+	res := &api.Session{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doSessionRefresh(ctx, req.(*api.SessionRefreshRequest), res.(*api.Session), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/SessionRefresh", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) SessionRefresh(ctx context.Context, req *api.SessionRefreshRequest, opts ...grpc.CallOption) (*api.Session, error) {
+func (c *NakamaClient) doSessionRefresh(ctx context.Context, req *api.SessionRefreshRequest, res *api.Session, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/session/refresh"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 	user, pass := httputil.GetBasicAuth(ctx)
-	httpReq.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.Session{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) SessionLogout(ctx context.Context, req *api.SessionLogoutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	res := &emptypb.Empty{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doSessionLogout(ctx, req.(*api.SessionLogoutRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/SessionLogout", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) SessionLogout(ctx context.Context, req *api.SessionLogoutRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doSessionLogout(ctx context.Context, req *api.SessionLogoutRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/session/logout"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) AuthenticateApple(ctx context.Context, req *api.AuthenticateAppleRequest, opts ...grpc.CallOption) (*api.Session, error) {
+	res := &api.Session{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doAuthenticateApple(ctx, req.(*api.AuthenticateAppleRequest), res.(*api.Session), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/AuthenticateApple", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) AuthenticateApple(ctx context.Context, req *api.AuthenticateAppleRequest, opts ...grpc.CallOption) (*api.Session, error) {
+func (c *NakamaClient) doAuthenticateApple(ctx context.Context, req *api.AuthenticateAppleRequest, res *api.Session, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/authenticate/apple"
 	reqBytes, err := protojson.Marshal(req.GetAccount())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Create != nil {
@@ -224,47 +279,56 @@ func (c *NakamaClient) AuthenticateApple(ctx context.Context, req *api.Authentic
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 	user, pass := httputil.GetBasicAuth(ctx)
-	httpReq.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) AuthenticateCustom(ctx context.Context, req *api.AuthenticateCustomRequest, opts ...grpc.CallOption) (*api.Session, error) {
 	res := &api.Session{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doAuthenticateCustom(ctx, req.(*api.AuthenticateCustomRequest), res.(*api.Session), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/AuthenticateCustom", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) AuthenticateCustom(ctx context.Context, req *api.AuthenticateCustomRequest, opts ...grpc.CallOption) (*api.Session, error) {
+func (c *NakamaClient) doAuthenticateCustom(ctx context.Context, req *api.AuthenticateCustomRequest, res *api.Session, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/authenticate/custom"
 	reqBytes, err := protojson.Marshal(req.GetAccount())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Create != nil {
@@ -274,47 +338,56 @@ func (c *NakamaClient) AuthenticateCustom(ctx context.Context, req *api.Authenti
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 	user, pass := httputil.GetBasicAuth(ctx)
-	httpReq.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) AuthenticateDevice(ctx context.Context, req *api.AuthenticateDeviceRequest, opts ...grpc.CallOption) (*api.Session, error) {
 	res := &api.Session{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doAuthenticateDevice(ctx, req.(*api.AuthenticateDeviceRequest), res.(*api.Session), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/AuthenticateDevice", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) AuthenticateDevice(ctx context.Context, req *api.AuthenticateDeviceRequest, opts ...grpc.CallOption) (*api.Session, error) {
+func (c *NakamaClient) doAuthenticateDevice(ctx context.Context, req *api.AuthenticateDeviceRequest, res *api.Session, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/authenticate/device"
 	reqBytes, err := protojson.Marshal(req.GetAccount())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Create != nil {
@@ -324,47 +397,56 @@ func (c *NakamaClient) AuthenticateDevice(ctx context.Context, req *api.Authenti
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 	user, pass := httputil.GetBasicAuth(ctx)
-	httpReq.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) AuthenticateEmail(ctx context.Context, req *api.AuthenticateEmailRequest, opts ...grpc.CallOption) (*api.Session, error) {
 	res := &api.Session{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doAuthenticateEmail(ctx, req.(*api.AuthenticateEmailRequest), res.(*api.Session), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/AuthenticateEmail", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) AuthenticateEmail(ctx context.Context, req *api.AuthenticateEmailRequest, opts ...grpc.CallOption) (*api.Session, error) {
+func (c *NakamaClient) doAuthenticateEmail(ctx context.Context, req *api.AuthenticateEmailRequest, res *api.Session, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/authenticate/email"
 	reqBytes, err := protojson.Marshal(req.GetAccount())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Create != nil {
@@ -374,47 +456,56 @@ func (c *NakamaClient) AuthenticateEmail(ctx context.Context, req *api.Authentic
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 	user, pass := httputil.GetBasicAuth(ctx)
-	httpReq.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) AuthenticateFacebook(ctx context.Context, req *api.AuthenticateFacebookRequest, opts ...grpc.CallOption) (*api.Session, error) {
 	res := &api.Session{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doAuthenticateFacebook(ctx, req.(*api.AuthenticateFacebookRequest), res.(*api.Session), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/AuthenticateFacebook", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) AuthenticateFacebook(ctx context.Context, req *api.AuthenticateFacebookRequest, opts ...grpc.CallOption) (*api.Session, error) {
+func (c *NakamaClient) doAuthenticateFacebook(ctx context.Context, req *api.AuthenticateFacebookRequest, res *api.Session, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/authenticate/facebook"
 	reqBytes, err := protojson.Marshal(req.GetAccount())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Create != nil {
@@ -427,47 +518,56 @@ func (c *NakamaClient) AuthenticateFacebook(ctx context.Context, req *api.Authen
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 	user, pass := httputil.GetBasicAuth(ctx)
-	httpReq.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) AuthenticateFacebookInstantGame(ctx context.Context, req *api.AuthenticateFacebookInstantGameRequest, opts ...grpc.CallOption) (*api.Session, error) {
 	res := &api.Session{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doAuthenticateFacebookInstantGame(ctx, req.(*api.AuthenticateFacebookInstantGameRequest), res.(*api.Session), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/AuthenticateFacebookInstantGame", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) AuthenticateFacebookInstantGame(ctx context.Context, req *api.AuthenticateFacebookInstantGameRequest, opts ...grpc.CallOption) (*api.Session, error) {
+func (c *NakamaClient) doAuthenticateFacebookInstantGame(ctx context.Context, req *api.AuthenticateFacebookInstantGameRequest, res *api.Session, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/authenticate/facebookinstantgame"
 	reqBytes, err := protojson.Marshal(req.GetAccount())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Create != nil {
@@ -477,47 +577,56 @@ func (c *NakamaClient) AuthenticateFacebookInstantGame(ctx context.Context, req
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 	user, pass := httputil.GetBasicAuth(ctx)
-	httpReq.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) AuthenticateGameCenter(ctx context.Context, req *api.AuthenticateGameCenterRequest, opts ...grpc.CallOption) (*api.Session, error) {
 	res := &api.Session{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doAuthenticateGameCenter(ctx, req.(*api.AuthenticateGameCenterRequest), res.(*api.Session), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/AuthenticateGameCenter", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) AuthenticateGameCenter(ctx context.Context, req *api.AuthenticateGameCenterRequest, opts ...grpc.CallOption) (*api.Session, error) {
+func (c *NakamaClient) doAuthenticateGameCenter(ctx context.Context, req *api.AuthenticateGameCenterRequest, res *api.Session, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/authenticate/gamecenter"
 	reqBytes, err := protojson.Marshal(req.GetAccount())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Create != nil {
@@ -527,47 +636,56 @@ func (c *NakamaClient) AuthenticateGameCenter(ctx context.Context, req *api.Auth
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 	user, pass := httputil.GetBasicAuth(ctx)
-	httpReq.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) AuthenticateGoogle(ctx context.Context, req *api.AuthenticateGoogleRequest, opts ...grpc.CallOption) (*api.Session, error) {
 	res := &api.Session{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doAuthenticateGoogle(ctx, req.(*api.AuthenticateGoogleRequest), res.(*api.Session), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/AuthenticateGoogle", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) AuthenticateGoogle(ctx context.Context, req *api.AuthenticateGoogleRequest, opts ...grpc.CallOption) (*api.Session, error) {
+func (c *NakamaClient) doAuthenticateGoogle(ctx context.Context, req *api.AuthenticateGoogleRequest, res *api.Session, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/authenticate/google"
 	reqBytes, err := protojson.Marshal(req.GetAccount())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Create != nil {
@@ -577,47 +695,56 @@ func (c *NakamaClient) AuthenticateGoogle(ctx context.Context, req *api.Authenti
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 	user, pass := httputil.GetBasicAuth(ctx)
-	httpReq.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) AuthenticateSteam(ctx context.Context, req *api.AuthenticateSteamRequest, opts ...grpc.CallOption) (*api.Session, error) {
 	res := &api.Session{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doAuthenticateSteam(ctx, req.(*api.AuthenticateSteamRequest), res.(*api.Session), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/AuthenticateSteam", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) AuthenticateSteam(ctx context.Context, req *api.AuthenticateSteamRequest, opts ...grpc.CallOption) (*api.Session, error) {
+func (c *NakamaClient) doAuthenticateSteam(ctx context.Context, req *api.AuthenticateSteamRequest, res *api.Session, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/authenticate/steam"
 	reqBytes, err := protojson.Marshal(req.GetAccount())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Create != nil {
@@ -630,42 +757,51 @@ func (c *NakamaClient) AuthenticateSteam(ctx context.Context, req *api.Authentic
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 	user, pass := httputil.GetBasicAuth(ctx)
-	httpReq.Header.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.Session{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) BanGroupUsers(ctx context.Context, req *api.BanGroupUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	res := &emptypb.Empty{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doBanGroupUsers(ctx, req.(*api.BanGroupUsersRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/BanGroupUsers", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) BanGroupUsers(ctx context.Context, req *api.BanGroupUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doBanGroupUsers(ctx context.Context, req *api.BanGroupUsersRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/group/%v/ban", req.GroupId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("group_id", req.GroupId)
@@ -675,41 +811,50 @@ func (c *NakamaClient) BanGroupUsers(ctx context.Context, req *api.BanGroupUsers
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) BlockFriends(ctx context.Context, req *api.BlockFriendsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doBlockFriends(ctx, req.(*api.BlockFriendsRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/BlockFriends", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) BlockFriends(ctx context.Context, req *api.BlockFriendsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doBlockFriends(ctx context.Context, req *api.BlockFriendsRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/friend/block"
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	for _, v := range req.Ids {
@@ -721,126 +866,153 @@ func (c *NakamaClient) BlockFriends(ctx context.Context, req *api.BlockFriendsRe
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) CreateGroup(ctx context.Context, req *api.CreateGroupRequest, opts ...grpc.CallOption) (*api.Group, error) {
+	res := &api.Group{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doCreateGroup(ctx, req.(*api.CreateGroupRequest), res.(*api.Group), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/CreateGroup", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) CreateGroup(ctx context.Context, req *api.CreateGroupRequest, opts ...grpc.CallOption) (*api.Group, error) {
+func (c *NakamaClient) doCreateGroup(ctx context.Context, req *api.CreateGroupRequest, res *api.Group, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/group"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.Group{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) DeleteAccount(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	res := &emptypb.Empty{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doDeleteAccount(ctx, req.(*emptypb.Empty), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/DeleteAccount", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) DeleteAccount(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doDeleteAccount(ctx context.Context, req *emptypb.Empty, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account"
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) DeleteFriends(ctx context.Context, req *api.DeleteFriendsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doDeleteFriends(ctx, req.(*api.DeleteFriendsRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/DeleteFriends", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) DeleteFriends(ctx context.Context, req *api.DeleteFriendsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doDeleteFriends(ctx context.Context, req *api.DeleteFriendsRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/friend"
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	for _, v := range req.Ids {
@@ -852,123 +1024,150 @@ func (c *NakamaClient) DeleteFriends(ctx context.Context, req *api.DeleteFriends
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) DeleteGroup(ctx context.Context, req *api.DeleteGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doDeleteGroup(ctx, req.(*api.DeleteGroupRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/DeleteGroup", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) DeleteGroup(ctx context.Context, req *api.DeleteGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doDeleteGroup(ctx context.Context, req *api.DeleteGroupRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/group/%v", req.GroupId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("group_id", req.GroupId)
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) DeleteLeaderboardRecord(ctx context.Context, req *api.DeleteLeaderboardRecordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doDeleteLeaderboardRecord(ctx, req.(*api.DeleteLeaderboardRecordRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/DeleteLeaderboardRecord", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) DeleteLeaderboardRecord(ctx context.Context, req *api.DeleteLeaderboardRecordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doDeleteLeaderboardRecord(ctx context.Context, req *api.DeleteLeaderboardRecordRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/leaderboard/%v", req.LeaderboardId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("leaderboard_id", req.LeaderboardId)
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) DeleteNotifications(ctx context.Context, req *api.DeleteNotificationsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doDeleteNotifications(ctx, req.(*api.DeleteNotificationsRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/DeleteNotifications", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) DeleteNotifications(ctx context.Context, req *api.DeleteNotificationsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doDeleteNotifications(ctx context.Context, req *api.DeleteNotificationsRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/notification"
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	for _, v := range req.Ids {
@@ -977,212 +1176,257 @@ func (c *NakamaClient) DeleteNotifications(ctx context.Context, req *api.DeleteN
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) DeleteTournamentRecord(ctx context.Context, req *api.DeleteTournamentRecordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doDeleteTournamentRecord(ctx, req.(*api.DeleteTournamentRecordRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/DeleteTournamentRecord", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) DeleteTournamentRecord(ctx context.Context, req *api.DeleteTournamentRecordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doDeleteTournamentRecord(ctx context.Context, req *api.DeleteTournamentRecordRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/tournament/%v", req.TournamentId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("tournament_id", req.TournamentId)
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "DELETE", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) DeleteStorageObjects(ctx context.Context, req *api.DeleteStorageObjectsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doDeleteStorageObjects(ctx, req.(*api.DeleteStorageObjectsRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/DeleteStorageObjects", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) DeleteStorageObjects(ctx context.Context, req *api.DeleteStorageObjectsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doDeleteStorageObjects(ctx context.Context, req *api.DeleteStorageObjectsRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/storage/delete"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "PUT", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) Event(ctx context.Context, req *api.Event, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doEvent(ctx, req.(*api.Event), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/Event", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) Event(ctx context.Context, req *api.Event, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doEvent(ctx context.Context, req *api.Event, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/event"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) GetAccount(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*api.Account, error) {
+	res := &api.Account{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doGetAccount(ctx, req.(*emptypb.Empty), res.(*api.Account), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/GetAccount", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) GetAccount(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*api.Account, error) {
+func (c *NakamaClient) doGetAccount(ctx context.Context, req *emptypb.Empty, res *api.Account, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account"
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.Account{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) GetUsers(ctx context.Context, req *api.GetUsersRequest, opts ...grpc.CallOption) (*api.Users, error) {
+	res := &api.Users{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doGetUsers(ctx, req.(*api.GetUsersRequest), res.(*api.Users), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/GetUsers", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) GetUsers(ctx context.Context, req *api.GetUsersRequest, opts ...grpc.CallOption) (*api.Users, error) {
+func (c *NakamaClient) doGetUsers(ctx context.Context, req *api.GetUsersRequest, res *api.Users, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/user"
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	for _, v := range req.Ids {
@@ -1197,127 +1441,154 @@ func (c *NakamaClient) GetUsers(ctx context.Context, req *api.GetUsersRequest, o
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.Users{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) GetSubscription(ctx context.Context, req *api.GetSubscriptionRequest, opts ...grpc.CallOption) (*api.ValidatedSubscription, error) {
+	res := &api.ValidatedSubscription{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doGetSubscription(ctx, req.(*api.GetSubscriptionRequest), res.(*api.ValidatedSubscription), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/GetSubscription", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) GetSubscription(ctx context.Context, req *api.GetSubscriptionRequest, opts ...grpc.CallOption) (*api.ValidatedSubscription, error) {
+func (c *NakamaClient) doGetSubscription(ctx context.Context, req *api.GetSubscriptionRequest, res *api.ValidatedSubscription, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/iap/subscription/%v", req.ProductId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("product_id", req.ProductId)
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.ValidatedSubscription{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) Healthcheck(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	res := &emptypb.Empty{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doHealthcheck(ctx, req.(*emptypb.Empty), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/Healthcheck", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) Healthcheck(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doHealthcheck(ctx context.Context, req *emptypb.Empty, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/healthcheck"
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ImportFacebookFriends(ctx context.Context, req *api.ImportFacebookFriendsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doImportFacebookFriends(ctx, req.(*api.ImportFacebookFriendsRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ImportFacebookFriends", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ImportFacebookFriends(ctx context.Context, req *api.ImportFacebookFriendsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doImportFacebookFriends(ctx context.Context, req *api.ImportFacebookFriendsRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/friend/facebook"
 	reqBytes, err := protojson.Marshal(req.GetAccount())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Reset_ != nil {
@@ -1326,46 +1597,55 @@ func (c *NakamaClient) ImportFacebookFriends(ctx context.Context, req *api.Impor
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ImportSteamFriends(ctx context.Context, req *api.ImportSteamFriendsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doImportSteamFriends(ctx, req.(*api.ImportSteamFriendsRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ImportSteamFriends", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ImportSteamFriends(ctx context.Context, req *api.ImportSteamFriendsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doImportSteamFriends(ctx context.Context, req *api.ImportSteamFriendsRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/friend/steam"
 	reqBytes, err := protojson.Marshal(req.GetAccount())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Reset_ != nil {
@@ -1374,123 +1654,150 @@ func (c *NakamaClient) ImportSteamFriends(ctx context.Context, req *api.ImportSt
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) JoinGroup(ctx context.Context, req *api.JoinGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doJoinGroup(ctx, req.(*api.JoinGroupRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/JoinGroup", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) JoinGroup(ctx context.Context, req *api.JoinGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doJoinGroup(ctx context.Context, req *api.JoinGroupRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/group/%v/join", req.GroupId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("group_id", req.GroupId)
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) JoinTournament(ctx context.Context, req *api.JoinTournamentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doJoinTournament(ctx, req.(*api.JoinTournamentRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/JoinTournament", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) JoinTournament(ctx context.Context, req *api.JoinTournamentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doJoinTournament(ctx context.Context, req *api.JoinTournamentRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/tournament/%v/join", req.TournamentId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("tournament_id", req.TournamentId)
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) KickGroupUsers(ctx context.Context, req *api.KickGroupUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doKickGroupUsers(ctx, req.(*api.KickGroupUsersRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/KickGroupUsers", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) KickGroupUsers(ctx context.Context, req *api.KickGroupUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doKickGroupUsers(ctx context.Context, req *api.KickGroupUsersRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/group/%v/kick", req.GroupId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("group_id", req.GroupId)
@@ -1500,267 +1807,321 @@ func (c *NakamaClient) KickGroupUsers(ctx context.Context, req *api.KickGroupUse
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) LeaveGroup(ctx context.Context, req *api.LeaveGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doLeaveGroup(ctx, req.(*api.LeaveGroupRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/LeaveGroup", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) LeaveGroup(ctx context.Context, req *api.LeaveGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doLeaveGroup(ctx context.Context, req *api.LeaveGroupRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/group/%v/leave", req.GroupId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("group_id", req.GroupId)
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) LinkApple(ctx context.Context, req *api.AccountApple, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doLinkApple(ctx, req.(*api.AccountApple), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/LinkApple", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) LinkApple(ctx context.Context, req *api.AccountApple, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doLinkApple(ctx context.Context, req *api.AccountApple, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/link/apple"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) LinkCustom(ctx context.Context, req *api.AccountCustom, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doLinkCustom(ctx, req.(*api.AccountCustom), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/LinkCustom", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) LinkCustom(ctx context.Context, req *api.AccountCustom, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doLinkCustom(ctx context.Context, req *api.AccountCustom, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/link/custom"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) LinkDevice(ctx context.Context, req *api.AccountDevice, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	res := &emptypb.Empty{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doLinkDevice(ctx, req.(*api.AccountDevice), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/LinkDevice", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) LinkDevice(ctx context.Context, req *api.AccountDevice, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doLinkDevice(ctx context.Context, req *api.AccountDevice, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/link/device"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) LinkEmail(ctx context.Context, req *api.AccountEmail, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doLinkEmail(ctx, req.(*api.AccountEmail), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/LinkEmail", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) LinkEmail(ctx context.Context, req *api.AccountEmail, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doLinkEmail(ctx context.Context, req *api.AccountEmail, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/link/email"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) LinkFacebook(ctx context.Context, req *api.LinkFacebookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doLinkFacebook(ctx, req.(*api.LinkFacebookRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/LinkFacebook", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) LinkFacebook(ctx context.Context, req *api.LinkFacebookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doLinkFacebook(ctx context.Context, req *api.LinkFacebookRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/link/facebook"
 	reqBytes, err := protojson.Marshal(req.GetAccount())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Sync != nil {
@@ -1769,221 +2130,266 @@ func (c *NakamaClient) LinkFacebook(ctx context.Context, req *api.LinkFacebookRe
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) LinkFacebookInstantGame(ctx context.Context, req *api.AccountFacebookInstantGame, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doLinkFacebookInstantGame(ctx, req.(*api.AccountFacebookInstantGame), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/LinkFacebookInstantGame", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) LinkFacebookInstantGame(ctx context.Context, req *api.AccountFacebookInstantGame, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doLinkFacebookInstantGame(ctx context.Context, req *api.AccountFacebookInstantGame, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/link/facebookinstantgame"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) LinkGameCenter(ctx context.Context, req *api.AccountGameCenter, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doLinkGameCenter(ctx, req.(*api.AccountGameCenter), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/LinkGameCenter", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) LinkGameCenter(ctx context.Context, req *api.AccountGameCenter, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doLinkGameCenter(ctx context.Context, req *api.AccountGameCenter, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/link/gamecenter"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) LinkGoogle(ctx context.Context, req *api.AccountGoogle, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doLinkGoogle(ctx, req.(*api.AccountGoogle), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/LinkGoogle", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) LinkGoogle(ctx context.Context, req *api.AccountGoogle, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doLinkGoogle(ctx context.Context, req *api.AccountGoogle, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/link/google"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) LinkSteam(ctx context.Context, req *api.LinkSteamRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doLinkSteam(ctx, req.(*api.LinkSteamRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/LinkSteam", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) LinkSteam(ctx context.Context, req *api.LinkSteamRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doLinkSteam(ctx context.Context, req *api.LinkSteamRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/link/steam"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListChannelMessages(ctx context.Context, req *api.ListChannelMessagesRequest, opts ...grpc.CallOption) (*api.ChannelMessageList, error) {
+	res := &api.ChannelMessageList{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListChannelMessages(ctx, req.(*api.ListChannelMessagesRequest), res.(*api.ChannelMessageList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListChannelMessages", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListChannelMessages(ctx context.Context, req *api.ListChannelMessagesRequest, opts ...grpc.CallOption) (*api.ChannelMessageList, error) {
+func (c *NakamaClient) doListChannelMessages(ctx context.Context, req *api.ListChannelMessagesRequest, res *api.ChannelMessageList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/channel/%v", req.ChannelId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("channel_id", req.ChannelId)
@@ -1997,41 +2403,50 @@ func (c *NakamaClient) ListChannelMessages(ctx context.Context, req *api.ListCha
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.ChannelMessageList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListFriends(ctx context.Context, req *api.ListFriendsRequest, opts ...grpc.CallOption) (*api.FriendList, error) {
+	res := &api.FriendList{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListFriends(ctx, req.(*api.ListFriendsRequest), res.(*api.FriendList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListFriends", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListFriends(ctx context.Context, req *api.ListFriendsRequest, opts ...grpc.CallOption) (*api.FriendList, error) {
+func (c *NakamaClient) doListFriends(ctx context.Context, req *api.ListFriendsRequest, res *api.FriendList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/friend"
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Limit != nil {
@@ -2044,41 +2459,50 @@ func (c *NakamaClient) ListFriends(ctx context.Context, req *api.ListFriendsRequ
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.FriendList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListGroups(ctx context.Context, req *api.ListGroupsRequest, opts ...grpc.CallOption) (*api.GroupList, error) {
+	res := &api.GroupList{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListGroups(ctx, req.(*api.ListGroupsRequest), res.(*api.GroupList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListGroups", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListGroups(ctx context.Context, req *api.ListGroupsRequest, opts ...grpc.CallOption) (*api.GroupList, error) {
+func (c *NakamaClient) doListGroups(ctx context.Context, req *api.ListGroupsRequest, res *api.GroupList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/group"
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("name", req.Name)
@@ -2096,41 +2520,50 @@ func (c *NakamaClient) ListGroups(ctx context.Context, req *api.ListGroupsReques
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.GroupList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListGroupUsers(ctx context.Context, req *api.ListGroupUsersRequest, opts ...grpc.CallOption) (*api.GroupUserList, error) {
+	res := &api.GroupUserList{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListGroupUsers(ctx, req.(*api.ListGroupUsersRequest), res.(*api.GroupUserList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListGroupUsers", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListGroupUsers(ctx context.Context, req *api.ListGroupUsersRequest, opts ...grpc.CallOption) (*api.GroupUserList, error) {
+func (c *NakamaClient) doListGroupUsers(ctx context.Context, req *api.ListGroupUsersRequest, res *api.GroupUserList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/group/%v/user", req.GroupId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("group_id", req.GroupId)
@@ -2144,41 +2577,50 @@ func (c *NakamaClient) ListGroupUsers(ctx context.Context, req *api.ListGroupUse
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.GroupUserList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListLeaderboardRecords(ctx context.Context, req *api.ListLeaderboardRecordsRequest, opts ...grpc.CallOption) (*api.LeaderboardRecordList, error) {
+	res := &api.LeaderboardRecordList{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListLeaderboardRecords(ctx, req.(*api.ListLeaderboardRecordsRequest), res.(*api.LeaderboardRecordList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListLeaderboardRecords", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListLeaderboardRecords(ctx context.Context, req *api.ListLeaderboardRecordsRequest, opts ...grpc.CallOption) (*api.LeaderboardRecordList, error) {
+func (c *NakamaClient) doListLeaderboardRecords(ctx context.Context, req *api.ListLeaderboardRecordsRequest, res *api.LeaderboardRecordList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/leaderboard/%v", req.LeaderboardId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("leaderboard_id", req.LeaderboardId)
@@ -2195,41 +2637,50 @@ func (c *NakamaClient) ListLeaderboardRecords(ctx context.Context, req *api.List
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListLeaderboardRecordsAroundOwner(ctx context.Context, req *api.ListLeaderboardRecordsAroundOwnerRequest, opts ...grpc.CallOption) (*api.LeaderboardRecordList, error) {
 	res := &api.LeaderboardRecordList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListLeaderboardRecordsAroundOwner(ctx, req.(*api.ListLeaderboardRecordsAroundOwnerRequest), res.(*api.LeaderboardRecordList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListLeaderboardRecordsAroundOwner", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListLeaderboardRecordsAroundOwner(ctx context.Context, req *api.ListLeaderboardRecordsAroundOwnerRequest, opts ...grpc.CallOption) (*api.LeaderboardRecordList, error) {
+func (c *NakamaClient) doListLeaderboardRecordsAroundOwner(ctx context.Context, req *api.ListLeaderboardRecordsAroundOwnerRequest, res *api.LeaderboardRecordList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/leaderboard/%v/owner/{owner_id}/v2/leaderboard/{leaderboard_id}/owner/%v", req.LeaderboardId, req.OwnerId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("leaderboard_id", req.LeaderboardId)
@@ -2244,41 +2695,50 @@ func (c *NakamaClient) ListLeaderboardRecordsAroundOwner(ctx context.Context, re
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.LeaderboardRecordList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListMatches(ctx context.Context, req *api.ListMatchesRequest, opts ...grpc.CallOption) (*api.MatchList, error) {
+	res := &api.MatchList{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListMatches(ctx, req.(*api.ListMatchesRequest), res.(*api.MatchList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListMatches", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListMatches(ctx context.Context, req *api.ListMatchesRequest, opts ...grpc.CallOption) (*api.MatchList, error) {
+func (c *NakamaClient) doListMatches(ctx context.Context, req *api.ListMatchesRequest, res *api.MatchList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/match"
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Limit != nil {
@@ -2302,41 +2762,50 @@ func (c *NakamaClient) ListMatches(ctx context.Context, req *api.ListMatchesRequ
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.MatchList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListNotifications(ctx context.Context, req *api.ListNotificationsRequest, opts ...grpc.CallOption) (*api.NotificationList, error) {
+	res := &api.NotificationList{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListNotifications(ctx, req.(*api.ListNotificationsRequest), res.(*api.NotificationList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListNotifications", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListNotifications(ctx context.Context, req *api.ListNotificationsRequest, opts ...grpc.CallOption) (*api.NotificationList, error) {
+func (c *NakamaClient) doListNotifications(ctx context.Context, req *api.ListNotificationsRequest, res *api.NotificationList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/notification"
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.Limit != nil {
@@ -2346,41 +2815,50 @@ func (c *NakamaClient) ListNotifications(ctx context.Context, req *api.ListNotif
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.NotificationList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListStorageObjects(ctx context.Context, req *api.ListStorageObjectsRequest, opts ...grpc.CallOption) (*api.StorageObjectList, error) {
+	res := &api.StorageObjectList{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListStorageObjects(ctx, req.(*api.ListStorageObjectsRequest), res.(*api.StorageObjectList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListStorageObjects", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListStorageObjects(ctx context.Context, req *api.ListStorageObjectsRequest, opts ...grpc.CallOption) (*api.StorageObjectList, error) {
+func (c *NakamaClient) doListStorageObjects(ctx context.Context, req *api.ListStorageObjectsRequest, res *api.StorageObjectList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/storage/%v/{user_id}/v2/storage/{collection}/%v", req.Collection, req.UserId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("user_id", req.UserId)
@@ -2392,41 +2870,50 @@ func (c *NakamaClient) ListStorageObjects(ctx context.Context, req *api.ListStor
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListStorageObjects2(ctx context.Context, req *api.ListStorageObjectsRequest, opts ...grpc.CallOption) (*api.StorageObjectList, error) {
 	res := &api.StorageObjectList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListStorageObjects2(ctx, req.(*api.ListStorageObjectsRequest), res.(*api.StorageObjectList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListStorageObjects", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListStorageObjects2(ctx context.Context, req *api.ListStorageObjectsRequest, opts ...grpc.CallOption) (*api.StorageObjectList, error) {
+func (c *NakamaClient) doListStorageObjects2(ctx context.Context, req *api.ListStorageObjectsRequest, res *api.StorageObjectList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/storage/%v", req.Collection)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("user_id", req.UserId)
@@ -2438,86 +2925,104 @@ func (c *NakamaClient) ListStorageObjects2(ctx context.Context, req *api.ListSto
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.StorageObjectList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListSubscriptions(ctx context.Context, req *api.ListSubscriptionsRequest, opts ...grpc.CallOption) (*api.SubscriptionList, error) {
+	res := &api.SubscriptionList{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListSubscriptions(ctx, req.(*api.ListSubscriptionsRequest), res.(*api.SubscriptionList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListSubscriptions", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListSubscriptions(ctx context.Context, req *api.ListSubscriptionsRequest, opts ...grpc.CallOption) (*api.SubscriptionList, error) {
+func (c *NakamaClient) doListSubscriptions(ctx context.Context, req *api.ListSubscriptionsRequest, res *api.SubscriptionList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/iap/subscription"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
-	httpRes, err := c.Client.Do(httpReq)
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	httpRes, err := c.Client.Do(httpReq)
+	if err != nil {
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.SubscriptionList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListTournaments(ctx context.Context, req *api.ListTournamentsRequest, opts ...grpc.CallOption) (*api.TournamentList, error) {
+	res := &api.TournamentList{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListTournaments(ctx, req.(*api.ListTournamentsRequest), res.(*api.TournamentList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListTournaments", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListTournaments(ctx context.Context, req *api.ListTournamentsRequest, opts ...grpc.CallOption) (*api.TournamentList, error) {
+func (c *NakamaClient) doListTournaments(ctx context.Context, req *api.ListTournamentsRequest, res *api.TournamentList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/tournament"
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	if req.CategoryStart != nil {
@@ -2539,41 +3044,50 @@ func (c *NakamaClient) ListTournaments(ctx context.Context, req *api.ListTournam
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.TournamentList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListTournamentRecords(ctx context.Context, req *api.ListTournamentRecordsRequest, opts ...grpc.CallOption) (*api.TournamentRecordList, error) {
+	res := &api.TournamentRecordList{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListTournamentRecords(ctx, req.(*api.ListTournamentRecordsRequest), res.(*api.TournamentRecordList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListTournamentRecords", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListTournamentRecords(ctx context.Context, req *api.ListTournamentRecordsRequest, opts ...grpc.CallOption) (*api.TournamentRecordList, error) {
+func (c *NakamaClient) doListTournamentRecords(ctx context.Context, req *api.ListTournamentRecordsRequest, res *api.TournamentRecordList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/tournament/%v", req.TournamentId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("tournament_id", req.TournamentId)
@@ -2590,41 +3104,50 @@ func (c *NakamaClient) ListTournamentRecords(ctx context.Context, req *api.ListT
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListTournamentRecordsAroundOwner(ctx context.Context, req *api.ListTournamentRecordsAroundOwnerRequest, opts ...grpc.CallOption) (*api.TournamentRecordList, error) {
 	res := &api.TournamentRecordList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListTournamentRecordsAroundOwner(ctx, req.(*api.ListTournamentRecordsAroundOwnerRequest), res.(*api.TournamentRecordList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListTournamentRecordsAroundOwner", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListTournamentRecordsAroundOwner(ctx context.Context, req *api.ListTournamentRecordsAroundOwnerRequest, opts ...grpc.CallOption) (*api.TournamentRecordList, error) {
+func (c *NakamaClient) doListTournamentRecordsAroundOwner(ctx context.Context, req *api.ListTournamentRecordsAroundOwnerRequest, res *api.TournamentRecordList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/tournament/%v/owner/{owner_id}/v2/tournament/{tournament_id}/owner/%v", req.TournamentId, req.OwnerId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("tournament_id", req.TournamentId)
@@ -2639,41 +3162,50 @@ func (c *NakamaClient) ListTournamentRecordsAroundOwner(ctx context.Context, req
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.TournamentRecordList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ListUserGroups(ctx context.Context, req *api.ListUserGroupsRequest, opts ...grpc.CallOption) (*api.UserGroupList, error) {
+	res := &api.UserGroupList{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doListUserGroups(ctx, req.(*api.ListUserGroupsRequest), res.(*api.UserGroupList), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ListUserGroups", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ListUserGroups(ctx context.Context, req *api.ListUserGroupsRequest, opts ...grpc.CallOption) (*api.UserGroupList, error) {
+func (c *NakamaClient) doListUserGroups(ctx context.Context, req *api.ListUserGroupsRequest, res *api.UserGroupList, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/user/%v/group", req.UserId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("user_id", req.UserId)
@@ -2687,41 +3219,50 @@ func (c *NakamaClient) ListUserGroups(ctx context.Context, req *api.ListUserGrou
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.UserGroupList{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) PromoteGroupUsers(ctx context.Context, req *api.PromoteGroupUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	res := &emptypb.Empty{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doPromoteGroupUsers(ctx, req.(*api.PromoteGroupUsersRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/PromoteGroupUsers", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) PromoteGroupUsers(ctx context.Context, req *api.PromoteGroupUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doPromoteGroupUsers(ctx context.Context, req *api.PromoteGroupUsersRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/group/%v/promote", req.GroupId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("group_id", req.GroupId)
@@ -2731,41 +3272,50 @@ func (c *NakamaClient) PromoteGroupUsers(ctx context.Context, req *api.PromoteGr
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) DemoteGroupUsers(ctx context.Context, req *api.DemoteGroupUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doDemoteGroupUsers(ctx, req.(*api.DemoteGroupUsersRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/DemoteGroupUsers", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) DemoteGroupUsers(ctx context.Context, req *api.DemoteGroupUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doDemoteGroupUsers(ctx context.Context, req *api.DemoteGroupUsersRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/group/%v/demote", req.GroupId)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("group_id", req.GroupId)
@@ -2775,86 +3325,104 @@ func (c *NakamaClient) DemoteGroupUsers(ctx context.Context, req *api.DemoteGrou
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ReadStorageObjects(ctx context.Context, req *api.ReadStorageObjectsRequest, opts ...grpc.CallOption) (*api.StorageObjects, error) {
+	res := &api.StorageObjects{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doReadStorageObjects(ctx, req.(*api.ReadStorageObjectsRequest), res.(*api.StorageObjects), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ReadStorageObjects", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ReadStorageObjects(ctx context.Context, req *api.ReadStorageObjectsRequest, opts ...grpc.CallOption) (*api.StorageObjects, error) {
+func (c *NakamaClient) doReadStorageObjects(ctx context.Context, req *api.ReadStorageObjectsRequest, res *api.StorageObjects, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/storage"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.StorageObjects{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) RpcFunc(ctx context.Context, req *api.Rpc, opts ...grpc.CallOption) (*api.Rpc, error) {
+	res := &api.Rpc{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doRpcFunc(ctx, req.(*api.Rpc), res.(*api.Rpc), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/RpcFunc", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) RpcFunc(ctx context.Context, req *api.Rpc, opts ...grpc.CallOption) (*api.Rpc, error) {
+func (c *NakamaClient) doRpcFunc(ctx context.Context, req *api.Rpc, res *api.Rpc, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/rpc/%v", req.Id)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("id", req.Id)
@@ -2864,46 +3432,55 @@ func (c *NakamaClient) RpcFunc(ctx context.Context, req *api.Rpc, opts ...grpc.C
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "GET", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) RpcFunc2(ctx context.Context, req *api.Rpc, opts ...grpc.CallOption) (*api.Rpc, error) {
 	res := &api.Rpc{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doRpcFunc2(ctx, req.(*api.Rpc), res.(*api.Rpc), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/RpcFunc", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) RpcFunc2(ctx context.Context, req *api.Rpc, opts ...grpc.CallOption) (*api.Rpc, error) {
+func (c *NakamaClient) doRpcFunc2(ctx context.Context, req *api.Rpc, res *api.Rpc, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/rpc/%v", req.Id)
 	reqBytes, err := json.Marshal(req.GetPayload())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("id", req.Id)
@@ -2912,979 +3489,1166 @@ func (c *NakamaClient) RpcFunc2(ctx context.Context, req *api.Rpc, opts ...grpc.
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.Rpc{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) UnlinkApple(ctx context.Context, req *api.AccountApple, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	res := &emptypb.Empty{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doUnlinkApple(ctx, req.(*api.AccountApple), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/UnlinkApple", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) UnlinkApple(ctx context.Context, req *api.AccountApple, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doUnlinkApple(ctx context.Context, req *api.AccountApple, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/unlink/apple"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) UnlinkCustom(ctx context.Context, req *api.AccountCustom, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doUnlinkCustom(ctx, req.(*api.AccountCustom), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/UnlinkCustom", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) UnlinkCustom(ctx context.Context, req *api.AccountCustom, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doUnlinkCustom(ctx context.Context, req *api.AccountCustom, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/unlink/custom"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) UnlinkDevice(ctx context.Context, req *api.AccountDevice, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doUnlinkDevice(ctx, req.(*api.AccountDevice), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/UnlinkDevice", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) UnlinkDevice(ctx context.Context, req *api.AccountDevice, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doUnlinkDevice(ctx context.Context, req *api.AccountDevice, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/unlink/device"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) UnlinkEmail(ctx context.Context, req *api.AccountEmail, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doUnlinkEmail(ctx, req.(*api.AccountEmail), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/UnlinkEmail", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) UnlinkEmail(ctx context.Context, req *api.AccountEmail, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doUnlinkEmail(ctx context.Context, req *api.AccountEmail, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/unlink/email"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) UnlinkFacebook(ctx context.Context, req *api.AccountFacebook, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doUnlinkFacebook(ctx, req.(*api.AccountFacebook), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/UnlinkFacebook", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) UnlinkFacebook(ctx context.Context, req *api.AccountFacebook, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doUnlinkFacebook(ctx context.Context, req *api.AccountFacebook, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/unlink/facebook"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) UnlinkFacebookInstantGame(ctx context.Context, req *api.AccountFacebookInstantGame, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doUnlinkFacebookInstantGame(ctx, req.(*api.AccountFacebookInstantGame), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/UnlinkFacebookInstantGame", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) UnlinkFacebookInstantGame(ctx context.Context, req *api.AccountFacebookInstantGame, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doUnlinkFacebookInstantGame(ctx context.Context, req *api.AccountFacebookInstantGame, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/unlink/facebookinstantgame"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) UnlinkGameCenter(ctx context.Context, req *api.AccountGameCenter, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doUnlinkGameCenter(ctx, req.(*api.AccountGameCenter), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/UnlinkGameCenter", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) UnlinkGameCenter(ctx context.Context, req *api.AccountGameCenter, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doUnlinkGameCenter(ctx context.Context, req *api.AccountGameCenter, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/unlink/gamecenter"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) UnlinkGoogle(ctx context.Context, req *api.AccountGoogle, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doUnlinkGoogle(ctx, req.(*api.AccountGoogle), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/UnlinkGoogle", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) UnlinkGoogle(ctx context.Context, req *api.AccountGoogle, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doUnlinkGoogle(ctx context.Context, req *api.AccountGoogle, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/unlink/google"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) UnlinkSteam(ctx context.Context, req *api.AccountSteam, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doUnlinkSteam(ctx, req.(*api.AccountSteam), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/UnlinkSteam", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) UnlinkSteam(ctx context.Context, req *api.AccountSteam, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doUnlinkSteam(ctx context.Context, req *api.AccountSteam, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account/unlink/steam"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) UpdateAccount(ctx context.Context, req *api.UpdateAccountRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doUpdateAccount(ctx, req.(*api.UpdateAccountRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/UpdateAccount", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) UpdateAccount(ctx context.Context, req *api.UpdateAccountRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doUpdateAccount(ctx context.Context, req *api.UpdateAccountRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/account"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "PUT", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) UpdateGroup(ctx context.Context, req *api.UpdateGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doUpdateGroup(ctx, req.(*api.UpdateGroupRequest), res.(*emptypb.Empty), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/UpdateGroup", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) UpdateGroup(ctx context.Context, req *api.UpdateGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *NakamaClient) doUpdateGroup(ctx context.Context, req *api.UpdateGroupRequest, res *emptypb.Empty, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/group/%v", req.GroupId)
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "PUT", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &emptypb.Empty{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ValidatePurchaseApple(ctx context.Context, req *api.ValidatePurchaseAppleRequest, opts ...grpc.CallOption) (*api.ValidatePurchaseResponse, error) {
+	res := &api.ValidatePurchaseResponse{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doValidatePurchaseApple(ctx, req.(*api.ValidatePurchaseAppleRequest), res.(*api.ValidatePurchaseResponse), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ValidatePurchaseApple", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ValidatePurchaseApple(ctx context.Context, req *api.ValidatePurchaseAppleRequest, opts ...grpc.CallOption) (*api.ValidatePurchaseResponse, error) {
+func (c *NakamaClient) doValidatePurchaseApple(ctx context.Context, req *api.ValidatePurchaseAppleRequest, res *api.ValidatePurchaseResponse, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/iap/purchase/apple"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.ValidatePurchaseResponse{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ValidateSubscriptionApple(ctx context.Context, req *api.ValidateSubscriptionAppleRequest, opts ...grpc.CallOption) (*api.ValidateSubscriptionResponse, error) {
+	res := &api.ValidateSubscriptionResponse{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doValidateSubscriptionApple(ctx, req.(*api.ValidateSubscriptionAppleRequest), res.(*api.ValidateSubscriptionResponse), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ValidateSubscriptionApple", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ValidateSubscriptionApple(ctx context.Context, req *api.ValidateSubscriptionAppleRequest, opts ...grpc.CallOption) (*api.ValidateSubscriptionResponse, error) {
+func (c *NakamaClient) doValidateSubscriptionApple(ctx context.Context, req *api.ValidateSubscriptionAppleRequest, res *api.ValidateSubscriptionResponse, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/iap/subscription/apple"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.ValidateSubscriptionResponse{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ValidatePurchaseGoogle(ctx context.Context, req *api.ValidatePurchaseGoogleRequest, opts ...grpc.CallOption) (*api.ValidatePurchaseResponse, error) {
+	res := &api.ValidatePurchaseResponse{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doValidatePurchaseGoogle(ctx, req.(*api.ValidatePurchaseGoogleRequest), res.(*api.ValidatePurchaseResponse), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ValidatePurchaseGoogle", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ValidatePurchaseGoogle(ctx context.Context, req *api.ValidatePurchaseGoogleRequest, opts ...grpc.CallOption) (*api.ValidatePurchaseResponse, error) {
+func (c *NakamaClient) doValidatePurchaseGoogle(ctx context.Context, req *api.ValidatePurchaseGoogleRequest, res *api.ValidatePurchaseResponse, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/iap/purchase/google"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.ValidatePurchaseResponse{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ValidateSubscriptionGoogle(ctx context.Context, req *api.ValidateSubscriptionGoogleRequest, opts ...grpc.CallOption) (*api.ValidateSubscriptionResponse, error) {
+	res := &api.ValidateSubscriptionResponse{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doValidateSubscriptionGoogle(ctx, req.(*api.ValidateSubscriptionGoogleRequest), res.(*api.ValidateSubscriptionResponse), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ValidateSubscriptionGoogle", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ValidateSubscriptionGoogle(ctx context.Context, req *api.ValidateSubscriptionGoogleRequest, opts ...grpc.CallOption) (*api.ValidateSubscriptionResponse, error) {
+func (c *NakamaClient) doValidateSubscriptionGoogle(ctx context.Context, req *api.ValidateSubscriptionGoogleRequest, res *api.ValidateSubscriptionResponse, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/iap/subscription/google"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.ValidateSubscriptionResponse{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ValidatePurchaseHuawei(ctx context.Context, req *api.ValidatePurchaseHuaweiRequest, opts ...grpc.CallOption) (*api.ValidatePurchaseResponse, error) {
+	res := &api.ValidatePurchaseResponse{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doValidatePurchaseHuawei(ctx, req.(*api.ValidatePurchaseHuaweiRequest), res.(*api.ValidatePurchaseResponse), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ValidatePurchaseHuawei", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ValidatePurchaseHuawei(ctx context.Context, req *api.ValidatePurchaseHuaweiRequest, opts ...grpc.CallOption) (*api.ValidatePurchaseResponse, error) {
+func (c *NakamaClient) doValidatePurchaseHuawei(ctx context.Context, req *api.ValidatePurchaseHuaweiRequest, res *api.ValidatePurchaseResponse, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/iap/purchase/huawei"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) ValidatePurchaseFacebookInstant(ctx context.Context, req *api.ValidatePurchaseFacebookInstantRequest, opts ...grpc.CallOption) (*api.ValidatePurchaseResponse, error) {
 	res := &api.ValidatePurchaseResponse{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doValidatePurchaseFacebookInstant(ctx, req.(*api.ValidatePurchaseFacebookInstantRequest), res.(*api.ValidatePurchaseResponse), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/ValidatePurchaseFacebookInstant", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) ValidatePurchaseFacebookInstant(ctx context.Context, req *api.ValidatePurchaseFacebookInstantRequest, opts ...grpc.CallOption) (*api.ValidatePurchaseResponse, error) {
+func (c *NakamaClient) doValidatePurchaseFacebookInstant(ctx context.Context, req *api.ValidatePurchaseFacebookInstantRequest, res *api.ValidatePurchaseResponse, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/iap/purchase/facebookinstant"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.ValidatePurchaseResponse{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) WriteLeaderboardRecord(ctx context.Context, req *api.WriteLeaderboardRecordRequest, opts ...grpc.CallOption) (*api.LeaderboardRecord, error) {
+	res := &api.LeaderboardRecord{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doWriteLeaderboardRecord(ctx, req.(*api.WriteLeaderboardRecordRequest), res.(*api.LeaderboardRecord), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/WriteLeaderboardRecord", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) WriteLeaderboardRecord(ctx context.Context, req *api.WriteLeaderboardRecordRequest, opts ...grpc.CallOption) (*api.LeaderboardRecord, error) {
+func (c *NakamaClient) doWriteLeaderboardRecord(ctx context.Context, req *api.WriteLeaderboardRecordRequest, res *api.LeaderboardRecord, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/leaderboard/%v", req.LeaderboardId)
 	reqBytes, err := protojson.Marshal(req.GetRecord())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("leaderboard_id", req.LeaderboardId)
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.LeaderboardRecord{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) WriteStorageObjects(ctx context.Context, req *api.WriteStorageObjectsRequest, opts ...grpc.CallOption) (*api.StorageObjectAcks, error) {
+	res := &api.StorageObjectAcks{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doWriteStorageObjects(ctx, req.(*api.WriteStorageObjectsRequest), res.(*api.StorageObjectAcks), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/WriteStorageObjects", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) WriteStorageObjects(ctx context.Context, req *api.WriteStorageObjectsRequest, opts ...grpc.CallOption) (*api.StorageObjectAcks, error) {
+func (c *NakamaClient) doWriteStorageObjects(ctx context.Context, req *api.WriteStorageObjectsRequest, res *api.StorageObjectAcks, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := "/v2/storage"
 	reqBytes, err := protojson.Marshal(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "PUT", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.StorageObjectAcks{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) WriteTournamentRecord(ctx context.Context, req *api.WriteTournamentRecordRequest, opts ...grpc.CallOption) (*api.LeaderboardRecord, error) {
+	res := &api.LeaderboardRecord{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doWriteTournamentRecord(ctx, req.(*api.WriteTournamentRecordRequest), res.(*api.LeaderboardRecord), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/WriteTournamentRecord", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) WriteTournamentRecord(ctx context.Context, req *api.WriteTournamentRecordRequest, opts ...grpc.CallOption) (*api.LeaderboardRecord, error) {
+func (c *NakamaClient) doWriteTournamentRecord(ctx context.Context, req *api.WriteTournamentRecordRequest, res *api.LeaderboardRecord, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/tournament/%v", req.TournamentId)
 	reqBytes, err := protojson.Marshal(req.GetRecord())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("tournament_id", req.TournamentId)
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return protojson.Unmarshal(resBytes, res)
+}
+
+func (c *NakamaClient) WriteTournamentRecord2(ctx context.Context, req *api.WriteTournamentRecordRequest, opts ...grpc.CallOption) (*api.LeaderboardRecord, error) {
 	res := &api.LeaderboardRecord{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doWriteTournamentRecord2(ctx, req.(*api.WriteTournamentRecordRequest), res.(*api.LeaderboardRecord), opts...)
+	}
+	if err := c.invoke(ctx, "/nakama.api.Nakama/WriteTournamentRecord", req, res, invoker, opts...); err != nil {
 		return nil, err
 	}
 	return res, nil
 }
 
-func (c *NakamaClient) WriteTournamentRecord2(ctx context.Context, req *api.WriteTournamentRecordRequest, opts ...grpc.CallOption) (*api.LeaderboardRecord, error) {
+func (c *NakamaClient) doWriteTournamentRecord2(ctx context.Context, req *api.WriteTournamentRecordRequest, res *api.LeaderboardRecord, opts ...grpc.CallOption) error {
 	var body io.Reader
 	path := fmt.Sprintf("/v2/tournament/%v", req.TournamentId)
 	reqBytes, err := protojson.Marshal(req.GetRecord())
 	if err != nil {
-		return nil, err
+		return err
 	}
 	body = bytes.NewReader(reqBytes)
 	u, err := url.Parse(c.URL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	q := &url.Values{}
 	q.Set("tournament_id", req.TournamentId)
 	u.RawQuery = q.Encode()
 	httpReq, err := http.NewRequestWithContext(ctx, "PUT", u.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	httpReq = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
 	if body != nil {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
-	httpReq.Header.Add("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq.Header.Set("Authorization", "Bearer "+httputil.GetBearerJWT(ctx))
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
 	httpRes, err := c.Client.Do(httpReq)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
 		_ = httpRes.Body.Close()
-		return nil, err
+		return err
 	}
-	httpRes = httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
 	resBytes, err := io.ReadAll(httpRes.Body)
 	_ = httpRes.Body.Close()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	res := &api.LeaderboardRecord{}
-	err = protojson.Unmarshal(resBytes, res)
-	if err != nil {
-		return nil, err
-	}
-	return res, nil
+	return protojson.Unmarshal(resBytes, res)
 }
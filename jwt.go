@@ -1,6 +1,8 @@
 package nakama_client_go
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -18,3 +20,35 @@ func unmarshalJWTBody(token string, v interface{}) error {
 	}
 	return json.Unmarshal(body, v)
 }
+
+// verifyJWTSignature checks a HS256 signature, as nakama signs session tokens with
+// session.encryption_key.
+func verifyJWTSignature(token string, key []byte) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 { //nolint:gomnd
+		return fmt.Errorf("%w: malformed jwt", ErrInvalidToken)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if header.Alg != "HS256" {
+		return fmt.Errorf("%w: unexpected signing method %q", ErrInvalidToken, header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+	}
+	return nil
+}
@@ -0,0 +1,310 @@
+package nakama_client_go
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultLockCollection = "locks"
+	defaultLeaderTTL      = 15 * time.Second
+)
+
+var (
+	ErrLockHeld = errors.New("lock held by another owner")
+	ErrLockLost = errors.New("lock lost")
+)
+
+// lockRecord is the stored state of a lock. Releasing a lock expires the record instead of
+// deleting it, so the token keeps increasing across owners.
+type lockRecord struct {
+	Owner     string    `json:"owner"`
+	Token     int64     `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type LockOption struct {
+	// Collection of the lock objects, defaults to "locks".
+	Collection string
+	// Owner identifies this holder, defaults to a random ID.
+	Owner string
+	// PollInterval of Acquire while the lock is held by another owner, defaults to a second.
+	PollInterval time.Duration
+	CallOptions  []CallOption
+}
+
+// Lock is a mutual exclusion lock stored as an object of the session user, using object
+// versions as compare-and-swap. Every process competing for the lock must authenticate as the
+// same user, since users can only write their own objects. Expiry uses the estimated server time.
+type Lock struct {
+	session *Session
+	records *Collection[lockRecord]
+	name    string
+	owner   string
+	poll    time.Duration
+
+	mu      *sync.Mutex
+	held    *StorageObject[lockRecord]
+	expires time.Time
+}
+
+func NewLock(session *Session, name string, opts ...LockOption) *Lock {
+	opt := LockOption{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Collection == "" {
+		opt.Collection = defaultLockCollection
+	}
+	if opt.Owner == "" {
+		opt.Owner = randomOwner()
+	}
+	if opt.PollInterval <= 0 {
+		opt.PollInterval = time.Second
+	}
+	return &Lock{
+		session: session,
+		records: NewCollection[lockRecord](session, opt.Collection, CollectionOption{
+			CallOptions: opt.CallOptions,
+		}),
+		name:  name,
+		owner: opt.Owner,
+		poll:  opt.PollInterval,
+		mu:    &sync.Mutex{},
+	}
+}
+
+func (l *Lock) Owner() string {
+	return l.owner
+}
+
+// Token is the fencing token of the current hold, it increases with every acquisition so
+// resources can reject writes from previous holders. It is 0 if the lock is not held.
+func (l *Lock) Token() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held == nil {
+		return 0
+	}
+	return l.held.Value.Token
+}
+
+// ExpiresAt is the server time at which the current hold expires unless renewed.
+func (l *Lock) ExpiresAt() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.expires
+}
+
+// TryAcquire takes the lock for ttl if it is free or expired, otherwise it returns ErrLockHeld.
+func (l *Lock) TryAcquire(ctx context.Context, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.session.client.now()
+	record := lockRecord{
+		Owner:     l.owner,
+		Token:     1,
+		ExpiresAt: now.Add(ttl),
+	}
+	current, err := l.records.Get(ctx, l.name)
+	var written *StorageObject[lockRecord]
+	switch {
+	case errors.Is(err, ErrNotFound):
+		written, err = l.records.Create(ctx, l.name, record)
+		if errors.Is(err, ErrAlreadyExists) {
+			return fmt.Errorf("lock %s: %w", l.name, ErrLockHeld)
+		}
+	case err != nil:
+		return err
+	case current.Value.Owner != l.owner && now.Before(current.Value.ExpiresAt):
+		return fmt.Errorf("lock %s: %w", l.name, ErrLockHeld)
+	default:
+		record.Token = current.Value.Token + 1
+		written, err = l.records.PutIfMatch(ctx, l.name, record, current.Version)
+		if errors.Is(err, ErrVersionConflict) {
+			return fmt.Errorf("lock %s: %w", l.name, ErrLockHeld)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	l.held = written
+	l.expires = record.ExpiresAt
+	return nil
+}
+
+// Acquire waits until the lock is taken or ctx is done.
+func (l *Lock) Acquire(ctx context.Context, ttl time.Duration) error {
+	for {
+		err := l.TryAcquire(ctx, ttl)
+		if !errors.Is(err, ErrLockHeld) {
+			return err
+		}
+		if !sleepContext(ctx, l.poll) {
+			return ctx.Err()
+		}
+	}
+}
+
+// Renew extends the hold by ttl from now, it returns ErrLockLost if another owner took the lock.
+func (l *Lock) Renew(ctx context.Context, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held == nil {
+		return fmt.Errorf("lock %s: %w", l.name, ErrLockLost)
+	}
+	record := l.held.Value
+	record.ExpiresAt = l.session.client.now().Add(ttl)
+	return l.swap(ctx, record, false)
+}
+
+// Release frees the lock, it returns ErrLockLost if another owner took it meanwhile.
+func (l *Lock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held == nil {
+		return nil
+	}
+	record := l.held.Value
+	record.ExpiresAt = time.Time{}
+	return l.swap(ctx, record, true)
+}
+
+func (l *Lock) swap(ctx context.Context, record lockRecord, release bool) error {
+	written, err := l.records.PutIfMatch(ctx, l.name, record, l.held.Version)
+	if errors.Is(err, ErrVersionConflict) {
+		l.held, l.expires = nil, time.Time{}
+		return fmt.Errorf("lock %s: %w: %w", l.name, ErrLockLost, err)
+	}
+	if err != nil {
+		return err
+	}
+	if release {
+		l.held, l.expires = nil, time.Time{}
+		return nil
+	}
+	l.held = written
+	l.expires = record.ExpiresAt
+	return nil
+}
+
+func (l *Lock) forget() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.held, l.expires = nil, time.Time{}
+}
+
+func randomOwner() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+type LeaderElectionOption struct {
+	// TTL of the leadership, defaults to 15 seconds.
+	TTL time.Duration
+	// RenewInterval defaults to a third of TTL, followers retry at the same interval.
+	RenewInterval time.Duration
+	LockOption
+}
+
+// LeaderElection elects a single leader among the processes campaigning for the same name.
+type LeaderElection struct {
+	lock     *Lock
+	ttl      time.Duration
+	interval time.Duration
+}
+
+func NewLeaderElection(session *Session, name string, opts ...LeaderElectionOption) *LeaderElection {
+	opt := LeaderElectionOption{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.TTL <= 0 {
+		opt.TTL = defaultLeaderTTL
+	}
+	if opt.RenewInterval <= 0 {
+		opt.RenewInterval = opt.TTL / 3
+	}
+	opt.PollInterval = opt.RenewInterval
+	return &LeaderElection{
+		lock:     NewLock(session, name, opt.LockOption),
+		ttl:      opt.TTL,
+		interval: opt.RenewInterval,
+	}
+}
+
+// IsLeader reports whether this process currently holds the leadership.
+func (e *LeaderElection) IsLeader() bool {
+	return e.lock.Token() != 0
+}
+
+// Run campaigns until ctx is done. Whenever elected, lead is called with the fencing token and
+// a context that is cancelled as soon as the leadership is lost, Run waits for lead to return
+// before campaigning again. The leadership is released when ctx is done or lead returns on its
+// own, in the latter case Run returns nil.
+func (e *LeaderElection) Run(ctx context.Context, lead func(ctx context.Context, token int64)) error {
+	for {
+		if err := e.lock.Acquire(ctx, e.ttl); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// transient failures are retried, the lock may still be free
+			if !sleepContext(ctx, e.interval) {
+				return ctx.Err()
+			}
+			continue
+		}
+
+		token := e.lock.Token()
+		leaderCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			lead(leaderCtx, token)
+		}()
+		lost := e.keepLeadership(leaderCtx, done)
+		cancel()
+		<-done
+
+		if lost && ctx.Err() == nil {
+			continue
+		}
+		releaseCtx, cancel := context.WithTimeout(context.Background(), e.interval)
+		_ = e.lock.Release(releaseCtx)
+		cancel()
+		return ctx.Err()
+	}
+}
+
+// keepLeadership renews the lock until ctx is done or lead returns, it reports true if the lock
+// was taken over or could not be renewed before it expired.
+func (e *LeaderElection) keepLeadership(ctx context.Context, done <-chan struct{}) bool {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-done:
+			return false
+		case <-ticker.C:
+		}
+		err := e.lock.Renew(ctx, e.ttl)
+		if errors.Is(err, ErrLockLost) {
+			return true
+		}
+		if err != nil && !e.lock.session.client.now().Before(e.lock.ExpiresAt()) {
+			e.lock.forget()
+			return true
+		}
+	}
+}
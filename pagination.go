@@ -0,0 +1,247 @@
+package nakama_client_go
+
+import (
+	"context"
+	"iter"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"google.golang.org/protobuf/proto"
+)
+
+type IterOption struct {
+	// Prefetch fetches the next page while the current one is consumed.
+	Prefetch    bool
+	CallOptions []CallOption
+}
+
+func parseIterOptions(opts ...IterOption) IterOption {
+	opt := IterOption{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	return opt
+}
+
+type pageResult[T any] struct {
+	items  []T
+	cursor string
+	err    error
+}
+
+// paginate follows cursors from cursor until a page is empty or has no next cursor. The iterator
+// stops after yielding the first error, including the context error once ctx is done.
+func paginate[T any](ctx context.Context, cursor string, prefetch bool, fetch func(ctx context.Context, cursor string) ([]T, string, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		fetchPage := func(cursor string) <-chan pageResult[T] {
+			ch := make(chan pageResult[T], 1)
+			run := func() {
+				items, next, err := fetch(ctx, cursor)
+				ch <- pageResult[T]{items: items, cursor: next, err: err}
+			}
+			if prefetch {
+				go run()
+			} else {
+				run()
+			}
+			return ch
+		}
+
+		var zero T
+		pending := fetchPage(cursor)
+		for {
+			var page pageResult[T]
+			select {
+			case <-ctx.Done():
+				yield(zero, ctx.Err())
+				return
+			case page = <-pending:
+			}
+			if page.err != nil {
+				yield(zero, page.err)
+				return
+			}
+
+			more := len(page.items) > 0 && page.cursor != "" && page.cursor != cursor
+			cursor = page.cursor
+			if more && prefetch {
+				pending = fetchPage(cursor)
+			}
+			for _, item := range page.items {
+				if err := ctx.Err(); err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !more {
+				return
+			}
+			if !prefetch {
+				pending = fetchPage(cursor)
+			}
+		}
+	}
+}
+
+// Collect gathers up to limit items from seq, or all of them if limit is not positive.
+func Collect[T any](seq iter.Seq2[T, error], limit int) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+		if limit > 0 && len(items) >= limit {
+			break
+		}
+	}
+	return items, nil
+}
+
+func (s *Session) ListChannelMessagesIter(ctx context.Context, req *api.ListChannelMessagesRequest, opts ...IterOption) iter.Seq2[*api.ChannelMessage, error] {
+	opt := parseIterOptions(opts...)
+	return paginate(ctx, req.Cursor, opt.Prefetch, func(ctx context.Context, cursor string) ([]*api.ChannelMessage, string, error) {
+		pageReq := proto.Clone(req).(*api.ListChannelMessagesRequest)
+		pageReq.Cursor = cursor
+		res, err := s.ListChannelMessages(ctx, pageReq, opt.CallOptions...)
+		if err != nil {
+			return nil, "", err
+		}
+		return res.Messages, res.NextCursor, nil
+	})
+}
+
+func (s *Session) ListFriendsIter(ctx context.Context, req *api.ListFriendsRequest, opts ...IterOption) iter.Seq2[*api.Friend, error] {
+	opt := parseIterOptions(opts...)
+	return paginate(ctx, req.Cursor, opt.Prefetch, func(ctx context.Context, cursor string) ([]*api.Friend, string, error) {
+		pageReq := proto.Clone(req).(*api.ListFriendsRequest)
+		pageReq.Cursor = cursor
+		res, err := s.ListFriends(ctx, pageReq, opt.CallOptions...)
+		if err != nil {
+			return nil, "", err
+		}
+		return res.Friends, res.Cursor, nil
+	})
+}
+
+func (s *Session) ListGroupsIter(ctx context.Context, req *api.ListGroupsRequest, opts ...IterOption) iter.Seq2[*api.Group, error] {
+	opt := parseIterOptions(opts...)
+	return paginate(ctx, req.Cursor, opt.Prefetch, func(ctx context.Context, cursor string) ([]*api.Group, string, error) {
+		pageReq := proto.Clone(req).(*api.ListGroupsRequest)
+		pageReq.Cursor = cursor
+		res, err := s.ListGroups(ctx, pageReq, opt.CallOptions...)
+		if err != nil {
+			return nil, "", err
+		}
+		return res.Groups, res.Cursor, nil
+	})
+}
+
+func (s *Session) ListGroupUsersIter(ctx context.Context, req *api.ListGroupUsersRequest, opts ...IterOption) iter.Seq2[*api.GroupUserList_GroupUser, error] {
+	opt := parseIterOptions(opts...)
+	return paginate(ctx, req.Cursor, opt.Prefetch, func(ctx context.Context, cursor string) ([]*api.GroupUserList_GroupUser, string, error) {
+		pageReq := proto.Clone(req).(*api.ListGroupUsersRequest)
+		pageReq.Cursor = cursor
+		res, err := s.ListGroupUsers(ctx, pageReq, opt.CallOptions...)
+		if err != nil {
+			return nil, "", err
+		}
+		return res.GroupUsers, res.Cursor, nil
+	})
+}
+
+func (s *Session) ListUserGroupsIter(ctx context.Context, req *api.ListUserGroupsRequest, opts ...IterOption) iter.Seq2[*api.UserGroupList_UserGroup, error] {
+	opt := parseIterOptions(opts...)
+	return paginate(ctx, req.Cursor, opt.Prefetch, func(ctx context.Context, cursor string) ([]*api.UserGroupList_UserGroup, string, error) {
+		pageReq := proto.Clone(req).(*api.ListUserGroupsRequest)
+		pageReq.Cursor = cursor
+		res, err := s.ListUserGroups(ctx, pageReq, opt.CallOptions...)
+		if err != nil {
+			return nil, "", err
+		}
+		return res.UserGroups, res.Cursor, nil
+	})
+}
+
+func (s *Session) ListNotificationsIter(ctx context.Context, req *api.ListNotificationsRequest, opts ...IterOption) iter.Seq2[*api.Notification, error] {
+	opt := parseIterOptions(opts...)
+	return paginate(ctx, req.CacheableCursor, opt.Prefetch, func(ctx context.Context, cursor string) ([]*api.Notification, string, error) {
+		pageReq := proto.Clone(req).(*api.ListNotificationsRequest)
+		pageReq.CacheableCursor = cursor
+		res, err := s.ListNotifications(ctx, pageReq, opt.CallOptions...)
+		if err != nil {
+			return nil, "", err
+		}
+		return res.Notifications, res.CacheableCursor, nil
+	})
+}
+
+func (s *Session) ListStorageObjectsIter(ctx context.Context, req *api.ListStorageObjectsRequest, opts ...IterOption) iter.Seq2[*api.StorageObject, error] {
+	opt := parseIterOptions(opts...)
+	return paginate(ctx, req.Cursor, opt.Prefetch, func(ctx context.Context, cursor string) ([]*api.StorageObject, string, error) {
+		pageReq := proto.Clone(req).(*api.ListStorageObjectsRequest)
+		pageReq.Cursor = cursor
+		res, err := s.ListStorageObjects(ctx, pageReq, opt.CallOptions...)
+		if err != nil {
+			return nil, "", err
+		}
+		return res.Objects, res.Cursor, nil
+	})
+}
+
+func (s *Session) ListLeaderboardRecordsIter(ctx context.Context, req *api.ListLeaderboardRecordsRequest, opts ...IterOption) iter.Seq2[*api.LeaderboardRecord, error] {
+	opt := parseIterOptions(opts...)
+	return paginate(ctx, req.Cursor, opt.Prefetch, func(ctx context.Context, cursor string) ([]*api.LeaderboardRecord, string, error) {
+		pageReq := proto.Clone(req).(*api.ListLeaderboardRecordsRequest)
+		pageReq.Cursor = cursor
+		res, err := s.ListLeaderboardRecords(ctx, pageReq, opt.CallOptions...)
+		if err != nil {
+			return nil, "", err
+		}
+		return res.Records, res.NextCursor, nil
+	})
+}
+
+func (s *Session) ListTournamentsIter(ctx context.Context, req *api.ListTournamentsRequest, opts ...IterOption) iter.Seq2[*api.Tournament, error] {
+	opt := parseIterOptions(opts...)
+	return paginate(ctx, req.Cursor, opt.Prefetch, func(ctx context.Context, cursor string) ([]*api.Tournament, string, error) {
+		pageReq := proto.Clone(req).(*api.ListTournamentsRequest)
+		pageReq.Cursor = cursor
+		res, err := s.ListTournaments(ctx, pageReq, opt.CallOptions...)
+		if err != nil {
+			return nil, "", err
+		}
+		return res.Tournaments, res.Cursor, nil
+	})
+}
+
+func (s *Session) ListTournamentRecordsIter(ctx context.Context, req *api.ListTournamentRecordsRequest, opts ...IterOption) iter.Seq2[*api.LeaderboardRecord, error] {
+	opt := parseIterOptions(opts...)
+	return paginate(ctx, req.Cursor, opt.Prefetch, func(ctx context.Context, cursor string) ([]*api.LeaderboardRecord, string, error) {
+		pageReq := proto.Clone(req).(*api.ListTournamentRecordsRequest)
+		pageReq.Cursor = cursor
+		res, err := s.ListTournamentRecords(ctx, pageReq, opt.CallOptions...)
+		if err != nil {
+			return nil, "", err
+		}
+		return res.Records, res.NextCursor, nil
+	})
+}
+
+func (s *Session) ListSubscriptionsIter(ctx context.Context, req *api.ListSubscriptionsRequest, opts ...IterOption) iter.Seq2[*api.ValidatedSubscription, error] {
+	opt := parseIterOptions(opts...)
+	return paginate(ctx, req.Cursor, opt.Prefetch, func(ctx context.Context, cursor string) ([]*api.ValidatedSubscription, string, error) {
+		pageReq := proto.Clone(req).(*api.ListSubscriptionsRequest)
+		pageReq.Cursor = cursor
+		res, err := s.ListSubscriptions(ctx, pageReq, opt.CallOptions...)
+		if err != nil {
+			return nil, "", err
+		}
+		return res.ValidatedSubscriptions, res.Cursor, nil
+	})
+}
@@ -11,8 +11,16 @@ import (
 )
 
 type RealtimeClient struct {
-	socket *Socket
-	chExit chan struct{}
+	mu       *sync.RWMutex
+	socket   *Socket
+	chExit   chan struct{}
+	stopOnce *sync.Once
+
+	state          *realtimeState
+	restoreMu      *sync.Mutex
+	reconnect      *ReconnectOption
+	onReconnecting func(attempt int, err error)
+	onReconnected  func(*ReconnectResult)
 
 	onExit              func(err error)
 	onNotification      func(*api.Notification)
@@ -41,15 +49,55 @@ func (s *Socket) Client() (*RealtimeClient, error) {
 		return nil, errors.New("already watching")
 	}
 	rc := &RealtimeClient{
+		mu:           &sync.RWMutex{},
 		socket:       s,
 		chExit:       make(chan struct{}),
+		stopOnce:     &sync.Once{},
+		state:        newRealtimeState(),
+		restoreMu:    &sync.Mutex{},
 		replyPending: &sync.Map{},
 	}
 	return rc, nil
 }
 
+// sock is the current socket, it is replaced when reconnecting.
+func (rc *RealtimeClient) sock() *Socket {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.socket
+}
+
+// Socket is the current socket of the client. With WithReconnect, the socket the client was
+// created from is closed by a reconnect and replaced, so it should not be kept around.
+func (rc *RealtimeClient) Socket() *Socket {
+	return rc.sock()
+}
+
+// Close stops the client and closes its current socket.
+func (rc *RealtimeClient) Close() error {
+	rc.stop()
+	// the socket is read after stopping, so a concurrent reconnect either sees the client stopped
+	// or has already swapped the socket
+	return rc.sock().Close()
+}
+
+func (rc *RealtimeClient) stop() {
+	rc.stopOnce.Do(func() {
+		close(rc.chExit)
+	})
+}
+
+func (rc *RealtimeClient) isStopped() bool {
+	select {
+	case <-rc.chExit:
+		return true
+	default:
+		return false
+	}
+}
+
 func (rc *RealtimeClient) Start() {
-	if rc.socket.watching {
+	if rc.sock().watching {
 		return
 	}
 	go rc.watch()
@@ -57,9 +105,9 @@ func (rc *RealtimeClient) Start() {
 
 //nolint:gocyclo
 func (rc *RealtimeClient) watch() {
-	rc.socket.watching = true
+	rc.sock().watching = true
 	defer func() {
-		rc.socket.watching = false
+		rc.sock().watching = false
 	}()
 
 	for {
@@ -67,8 +115,16 @@ func (rc *RealtimeClient) watch() {
 		case <-rc.chExit:
 			return
 		default:
-			ev, err := rc.socket.Read(context.Background())
+			ev, err := rc.sock().Read(context.Background())
 			if err != nil {
+				// a socket closed on purpose is not reconnected
+				if rc.reconnect != nil && !rc.sock().isClosed() {
+					var reconnected bool
+					if reconnected, err = rc.redial(err); reconnected {
+						go rc.restoreAndNotify()
+						continue
+					}
+				}
 				if rc.onExit != nil {
 					rc.onExit(err)
 				}
@@ -95,6 +151,9 @@ func (rc *RealtimeClient) watch() {
 					rc.onMatchmakerTicket(m.MatchmakerTicket)
 				}
 			case *rtapi.Envelope_MatchmakerMatched:
+				rc.state.update(func(st *realtimeState) {
+					delete(st.matchers, m.MatchmakerMatched.GetTicket())
+				})
 				if rc.onMatchmakerMatched != nil {
 					rc.onMatchmakerMatched(m.MatchmakerMatched)
 				}
@@ -127,6 +186,9 @@ func (rc *RealtimeClient) watch() {
 					rc.onPartyPresence(m.PartyPresenceEvent)
 				}
 			case *rtapi.Envelope_PartyClose:
+				rc.state.update(func(st *realtimeState) {
+					delete(st.parties, m.PartyClose.GetPartyId())
+				})
 				if rc.onPartyClose != nil {
 					rc.onPartyClose(m.PartyClose)
 				}
@@ -149,17 +211,22 @@ func (rc *RealtimeClient) watch() {
 			default:
 				v, ok := rc.replyPending.LoadAndDelete(ev.Cid)
 				if !ok {
-					rc.Stop(fmt.Errorf("no reply handler for message: %v", ev))
+					// replies to requests given up on by the caller are dropped
+					if ev.Cid == "" {
+						rc.Stop(fmt.Errorf("no reply handler for message: %v", ev))
+					}
+					continue
 				}
-				ch := v.(chan *rtapi.Envelope)
-				ch <- ev
+				// buffered and never closed, the reply is collected with the channel if the caller
+				// gave up meanwhile
+				v.(chan *rtapi.Envelope) <- ev
 			}
 		}
 	}
 }
 
 func (rc *RealtimeClient) Stop(err error) {
-	close(rc.chExit)
+	rc.stop()
 	if err != nil {
 		rc.onExit(nil)
 	}
@@ -256,18 +323,23 @@ func (rc *RealtimeClient) OnParty(f func(*rtapi.Party)) *RealtimeClient {
 }
 
 func (rc *RealtimeClient) sendForResponse(ctx context.Context, envelope *rtapi.Envelope) (*rtapi.Envelope, error) {
-	cid := rc.socket.newCID()
+	sock := rc.sock()
+	cid := sock.newCID()
 	envelope.Cid = cid
 	ch := make(chan *rtapi.Envelope, 1)
-	defer close(ch)
 	rc.replyPending.Store(cid, ch)
-	if err := rc.socket.Write(ctx, envelope); err != nil {
+	if err := sock.Write(ctx, envelope); err != nil {
+		rc.replyPending.Delete(cid)
 		return nil, err
 	}
 
 	select {
 	case <-ctx.Done():
+		rc.replyPending.Delete(cid)
 		return nil, ctx.Err()
+	case <-sock.closed:
+		rc.replyPending.Delete(cid)
+		return nil, ErrSocketClosed
 	case reply := <-ch:
 		if e, ok := reply.Message.(*rtapi.Envelope_Error); ok {
 			return nil, &RealtimeError{err: e.Error}
@@ -277,7 +349,7 @@ func (rc *RealtimeClient) sendForResponse(ctx context.Context, envelope *rtapi.E
 }
 
 func (rc *RealtimeClient) AcceptPartyMember(ctx context.Context, partyID string, presence *rtapi.UserPresence) error {
-	return rc.socket.Write(ctx, &rtapi.Envelope{
+	return rc.sock().Write(ctx, &rtapi.Envelope{
 		Message: &rtapi.Envelope_PartyAccept{
 			PartyAccept: &rtapi.PartyAccept{
 				PartyId:  partyID,
@@ -302,6 +374,15 @@ func (rc *RealtimeClient) AddMatchmaker(ctx context.Context, minCount, maxCount
 	if err != nil {
 		return nil, err
 	}
+	rc.state.update(func(st *realtimeState) {
+		st.matchers[ev.GetMatchmakerTicket().GetTicket()] = matchmakerAdd{
+			minCount:          minCount,
+			maxCount:          maxCount,
+			query:             query,
+			stringProperties:  stringProperties,
+			numericProperties: numericProperties,
+		}
+	})
 	return ev.GetMatchmakerTicket(), nil
 }
 
@@ -332,7 +413,13 @@ func (rc *RealtimeClient) CloseParty(ctx context.Context, partyID string) error
 			},
 		},
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	rc.state.update(func(st *realtimeState) {
+		delete(st.parties, partyID)
+	})
+	return nil
 }
 
 func (rc *RealtimeClient) CreateMatch(ctx context.Context, name string) (*rtapi.Match, error) {
@@ -346,6 +433,9 @@ func (rc *RealtimeClient) CreateMatch(ctx context.Context, name string) (*rtapi.
 	if err != nil {
 		return nil, err
 	}
+	rc.state.update(func(st *realtimeState) {
+		st.matches[ev.GetMatch().GetMatchId()] = nil
+	})
 	return ev.GetMatch(), nil
 }
 
@@ -361,6 +451,9 @@ func (rc *RealtimeClient) CreateParty(ctx context.Context, open bool, maxSize in
 	if err != nil {
 		return nil, err
 	}
+	rc.state.update(func(st *realtimeState) {
+		st.parties[ev.GetParty().GetPartyId()] = struct{}{}
+	})
 	return ev.GetParty(), nil
 }
 
@@ -375,6 +468,11 @@ func (rc *RealtimeClient) FollowUsers(ctx context.Context, userIds []string) (*r
 	if err != nil {
 		return nil, err
 	}
+	rc.state.update(func(st *realtimeState) {
+		for _, userID := range userIds {
+			st.follows[userID] = struct{}{}
+		}
+	})
 	return ev.GetStatus(), nil
 }
 
@@ -401,6 +499,9 @@ func (rc *RealtimeClient) JoinChat(ctx context.Context, target string, typ rtapi
 	if err != nil {
 		return nil, err
 	}
+	rc.state.update(func(st *realtimeState) {
+		st.chats[ev.GetChannel().GetId()] = chatJoin{target: target, typ: typ, opt: opt}
+	})
 	return ev.GetChannel(), nil
 }
 
@@ -425,6 +526,9 @@ func (rc *RealtimeClient) JoinMatch(ctx context.Context, matchID, token string,
 	if err != nil {
 		return nil, err
 	}
+	rc.state.update(func(st *realtimeState) {
+		st.matches[ev.GetMatch().GetMatchId()] = metadata
+	})
 	return ev.GetMatch(), nil
 }
 
@@ -436,7 +540,13 @@ func (rc *RealtimeClient) JoinParty(ctx context.Context, partyID string) error {
 			},
 		},
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	rc.state.update(func(st *realtimeState) {
+		st.parties[partyID] = struct{}{}
+	})
+	return nil
 }
 
 func (rc *RealtimeClient) LeaveChat(ctx context.Context, channelID string) error {
@@ -447,7 +557,13 @@ func (rc *RealtimeClient) LeaveChat(ctx context.Context, channelID string) error
 			},
 		},
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	rc.state.update(func(st *realtimeState) {
+		delete(st.chats, channelID)
+	})
+	return nil
 }
 
 func (rc *RealtimeClient) LeaveMatch(ctx context.Context, matchID string) error {
@@ -458,7 +574,13 @@ func (rc *RealtimeClient) LeaveMatch(ctx context.Context, matchID string) error
 			},
 		},
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	rc.state.update(func(st *realtimeState) {
+		delete(st.matches, matchID)
+	})
+	return nil
 }
 
 func (rc *RealtimeClient) LeaveParty(ctx context.Context, partyID string) error {
@@ -469,7 +591,13 @@ func (rc *RealtimeClient) LeaveParty(ctx context.Context, partyID string) error
 			},
 		},
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	rc.state.update(func(st *realtimeState) {
+		delete(st.parties, partyID)
+	})
+	return nil
 }
 
 func (rc *RealtimeClient) ListPartyJoinRequests(ctx context.Context, partyID string) (*rtapi.PartyJoinRequest, error) {
@@ -524,7 +652,13 @@ func (rc *RealtimeClient) RemoveMatchmaker(ctx context.Context, ticket string) e
 			},
 		},
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	rc.state.update(func(st *realtimeState) {
+		delete(st.matchers, ticket)
+	})
+	return nil
 }
 
 func (rc *RealtimeClient) RemoveMatchmakerParty(ctx context.Context, partyID, ticket string) error {
@@ -568,7 +702,7 @@ func (rc *RealtimeClient) RPC(ctx context.Context, id, payload, httpKey string)
 }
 
 func (rc *RealtimeClient) SendMatchState(ctx context.Context, matchID string, opCode int64, data []byte, presences []*rtapi.UserPresence, reliable bool) error {
-	return rc.socket.Write(ctx, &rtapi.Envelope{
+	return rc.sock().Write(ctx, &rtapi.Envelope{
 		Message: &rtapi.Envelope_MatchDataSend{
 			MatchDataSend: &rtapi.MatchDataSend{
 				MatchId:   matchID,
@@ -581,7 +715,7 @@ func (rc *RealtimeClient) SendMatchState(ctx context.Context, matchID string, op
 	})
 }
 func (rc *RealtimeClient) SendPartyData(ctx context.Context, partyID string, opCode int64, data []byte) error {
-	return rc.socket.Write(ctx, &rtapi.Envelope{
+	return rc.sock().Write(ctx, &rtapi.Envelope{
 		Message: &rtapi.Envelope_PartyDataSend{
 			PartyDataSend: &rtapi.PartyDataSend{
 				PartyId: partyID,
@@ -600,7 +734,13 @@ func (rc *RealtimeClient) UpdateStatus(ctx context.Context, status *string) erro
 			},
 		},
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	rc.state.update(func(st *realtimeState) {
+		st.status = status
+	})
+	return nil
 }
 
 func (rc *RealtimeClient) WriteChatMessage(ctx context.Context, channelID, content string) (*rtapi.ChannelMessageAck, error) {
@@ -638,13 +778,19 @@ func (e *RealtimeError) Error() string {
 	return fmt.Sprintf("realtime error(code=%d, message=%s, context=%v)", e.err.Code, e.err.Message, e.err.Context)
 }
 
+func (e *RealtimeError) Is(target error) bool {
+	sentinel, ok := realtimeCodeErrors[rtapi.Error_Code(e.err.Code)]
+	return ok && sentinel == target
+}
+
 func IsRealtimeError(err error) bool {
-	_, ok := err.(*RealtimeError)
-	return ok
+	var e *RealtimeError
+	return errors.As(err, &e)
 }
 
 func AsRealtimeError(err error) *RealtimeError {
-	if e, ok := err.(*RealtimeError); ok {
+	var e *RealtimeError
+	if errors.As(err, &e) {
 		return e
 	}
 	return nil
@@ -0,0 +1,286 @@
+package nakama_client_go
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/rtapi"
+)
+
+const defaultReconnectDialTimeout = 10 * time.Second
+
+// NoJitter disables the jitter of ReconnectOption, where a zero Jitter takes the default.
+const NoJitter = -1
+
+var DefaultReconnectOption = ReconnectOption{
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+// ReconnectOption fields left zero take the value of DefaultReconnectOption.
+type ReconnectOption struct {
+	// MaxAttempts of dialing after a disconnect, 0 retries until the client is stopped.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter randomizes each backoff by up to this fraction, in [0, 1], or NoJitter.
+	Jitter float64
+	// DialTimeout bounds each attempt, defaults to 10 seconds.
+	DialTimeout time.Duration
+}
+
+// ReconnectResult tells what was restored after a reconnect. Matchmaker tickets are issued
+// again, so they get new IDs.
+type ReconnectResult struct {
+	// Tickets maps previous matchmaker tickets to the tickets issued after reconnecting.
+	Tickets map[string]string
+	// Unrestored holds an error for every chat, follow, party, match or ticket that could not be
+	// restored. Those the server rejected are no longer tracked, those that failed because the
+	// connection dropped again are restored after the next reconnect.
+	Unrestored []error
+}
+
+type chatJoin struct {
+	target string
+	typ    rtapi.ChannelJoin_Type
+	opt    JoinChatOption
+}
+
+type matchmakerAdd struct {
+	minCount, maxCount int
+	query              string
+	stringProperties   map[string]string
+	numericProperties  map[string]float64
+}
+
+// realtimeState is what the client joined, restored after reconnecting.
+type realtimeState struct {
+	mu       *sync.Mutex
+	chats    map[string]chatJoin
+	follows  map[string]struct{}
+	status   *string
+	parties  map[string]struct{}
+	matches  map[string]map[string]string
+	matchers map[string]matchmakerAdd
+}
+
+func newRealtimeState() *realtimeState {
+	return &realtimeState{
+		mu:       &sync.Mutex{},
+		chats:    map[string]chatJoin{},
+		follows:  map[string]struct{}{},
+		parties:  map[string]struct{}{},
+		matches:  map[string]map[string]string{},
+		matchers: map[string]matchmakerAdd{},
+	}
+}
+
+func (st *realtimeState) update(f func(st *realtimeState)) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	f(st)
+}
+
+// snapshot takes the tracked state and starts over, restoring it tracks it again.
+func (st *realtimeState) snapshot() *realtimeState {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	snap := &realtimeState{
+		mu:       &sync.Mutex{},
+		chats:    st.chats,
+		follows:  st.follows,
+		status:   st.status,
+		parties:  st.parties,
+		matches:  st.matches,
+		matchers: st.matchers,
+	}
+	fresh := newRealtimeState()
+	st.chats, st.follows, st.status = fresh.chats, fresh.follows, nil
+	st.parties, st.matches, st.matchers = fresh.parties, fresh.matches, fresh.matchers
+	return snap
+}
+
+// WithReconnect redials with backoff when the socket drops and restores chats, status follows,
+// parties, matches and matchmaker tickets, instead of stopping the client.
+func (rc *RealtimeClient) WithReconnect(opts ...ReconnectOption) *RealtimeClient {
+	opt := DefaultReconnectOption
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.InitialBackoff <= 0 {
+		opt.InitialBackoff = DefaultReconnectOption.InitialBackoff
+	}
+	if opt.MaxBackoff <= 0 {
+		opt.MaxBackoff = DefaultReconnectOption.MaxBackoff
+	}
+	if opt.Multiplier <= 0 {
+		opt.Multiplier = DefaultReconnectOption.Multiplier
+	}
+	switch {
+	case opt.Jitter < 0:
+		opt.Jitter = 0
+	case opt.Jitter == 0:
+		opt.Jitter = DefaultReconnectOption.Jitter
+	}
+	if opt.DialTimeout <= 0 {
+		opt.DialTimeout = defaultReconnectDialTimeout
+	}
+	rc.reconnect = &opt
+	return rc
+}
+
+// OnReconnecting is called before every attempt with the error that caused the disconnect or
+// failed the previous attempt.
+func (rc *RealtimeClient) OnReconnecting(f func(attempt int, err error)) *RealtimeClient {
+	rc.onReconnecting = f
+	return rc
+}
+
+// OnReconnected is called once the state is restored.
+func (rc *RealtimeClient) OnReconnected(f func(*ReconnectResult)) *RealtimeClient {
+	rc.onReconnected = f
+	return rc
+}
+
+// redial replaces the socket, it returns false if the client is stopped or every attempt failed.
+func (rc *RealtimeClient) redial(cause error) (bool, error) {
+	policy := RetryPolicy{
+		InitialBackoff: rc.reconnect.InitialBackoff,
+		MaxBackoff:     rc.reconnect.MaxBackoff,
+		Multiplier:     rc.reconnect.Multiplier,
+		Jitter:         rc.reconnect.Jitter,
+	}
+	old := rc.sock()
+	_ = old.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-rc.chExit:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err := cause
+	for attempt := 0; rc.reconnect.MaxAttempts == 0 || attempt < rc.reconnect.MaxAttempts; attempt++ {
+		if rc.onReconnecting != nil {
+			rc.onReconnecting(attempt+1, err)
+		}
+		if !sleepContext(ctx, policy.backoff(attempt)) {
+			return false, err
+		}
+
+		dialCtx, dialCancel := context.WithTimeout(ctx, rc.reconnect.DialTimeout)
+		var sock *Socket
+		sock, err = old.redial(dialCtx)
+		dialCancel()
+		if err == nil {
+			sock.watching = true
+			rc.mu.Lock()
+			if rc.isStopped() {
+				rc.mu.Unlock()
+				_ = sock.Close()
+				return false, cause
+			}
+			rc.socket = sock
+			rc.mu.Unlock()
+			return true, nil
+		}
+		if ctx.Err() != nil {
+			return false, err
+		}
+	}
+	return false, err
+}
+
+// restore joins again what the client had joined before the disconnect, it runs while the new
+// socket is read so replies are delivered. Restores run one at a time, so a restore after another
+// drop sees what the previous one put back.
+func (rc *RealtimeClient) restore(ctx context.Context) *ReconnectResult {
+	rc.restoreMu.Lock()
+	defer rc.restoreMu.Unlock()
+
+	snap := rc.state.snapshot()
+	result := &ReconnectResult{
+		Tickets: map[string]string{},
+	}
+	// failed reports err, entries the server did not reject are tracked again by keep
+	failed := func(what string, err error, keep func(st *realtimeState)) {
+		result.Unrestored = append(result.Unrestored, fmt.Errorf("%s: %w", what, err))
+		if !IsRealtimeError(err) {
+			rc.state.update(keep)
+		}
+	}
+
+	for channelID, join := range snap.chats {
+		if _, err := rc.JoinChat(ctx, join.target, join.typ, join.opt); err != nil {
+			failed("chat "+channelID, err, func(st *realtimeState) {
+				st.chats[channelID] = join
+			})
+		}
+	}
+	if len(snap.follows) > 0 {
+		userIDs := make([]string, 0, len(snap.follows))
+		for userID := range snap.follows {
+			userIDs = append(userIDs, userID)
+		}
+		if _, err := rc.FollowUsers(ctx, userIDs); err != nil {
+			failed("status follows", err, func(st *realtimeState) {
+				for _, userID := range userIDs {
+					st.follows[userID] = struct{}{}
+				}
+			})
+		}
+	}
+	if snap.status != nil {
+		if err := rc.UpdateStatus(ctx, snap.status); err != nil {
+			failed("status", err, func(st *realtimeState) {
+				if st.status == nil {
+					st.status = snap.status
+				}
+			})
+		}
+	}
+	for partyID := range snap.parties {
+		if err := rc.JoinParty(ctx, partyID); err != nil {
+			failed("party "+partyID, err, func(st *realtimeState) {
+				st.parties[partyID] = struct{}{}
+			})
+		}
+	}
+	// relayed matches end once every player left, so only matches that still run are rejoined
+	for matchID, metadata := range snap.matches {
+		if _, err := rc.JoinMatch(ctx, matchID, "", metadata); err != nil {
+			failed("match "+matchID, err, func(st *realtimeState) {
+				st.matches[matchID] = metadata
+			})
+		}
+	}
+	for ticket, add := range snap.matchers {
+		issued, err := rc.AddMatchmaker(ctx, add.minCount, add.maxCount, add.query, add.stringProperties, add.numericProperties)
+		if err != nil {
+			failed("matchmaker ticket "+ticket, err, func(st *realtimeState) {
+				st.matchers[ticket] = add
+			})
+			continue
+		}
+		result.Tickets[ticket] = issued.GetTicket()
+	}
+	return result
+}
+
+func (rc *RealtimeClient) restoreAndNotify() {
+	ctx, cancel := context.WithTimeout(context.Background(), rc.reconnect.DialTimeout)
+	defer cancel()
+	result := rc.restore(ctx)
+	if rc.onReconnected != nil {
+		rc.onReconnected(result)
+	}
+}
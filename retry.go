@@ -0,0 +1,189 @@
+package nakama_client_go
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	headerRetryAfter = "retry-after"
+	// defaultMaxRetryAfter caps Retry-After delays of policies without MaxBackoff.
+	defaultMaxRetryAfter = time.Minute
+)
+
+// idempotentMethods are retried by default, everything else has side effects on the server.
+var idempotentMethods = map[string]bool{
+	"/nakama.api.Nakama/GetAccount":                        true,
+	"/nakama.api.Nakama/GetUsers":                          true,
+	"/nakama.api.Nakama/GetSubscription":                   true,
+	"/nakama.api.Nakama/Healthcheck":                       true,
+	"/nakama.api.Nakama/ListChannelMessages":               true,
+	"/nakama.api.Nakama/ListFriends":                       true,
+	"/nakama.api.Nakama/ListGroups":                        true,
+	"/nakama.api.Nakama/ListGroupUsers":                    true,
+	"/nakama.api.Nakama/ListLeaderboardRecords":            true,
+	"/nakama.api.Nakama/ListLeaderboardRecordsAroundOwner": true,
+	"/nakama.api.Nakama/ListMatches":                       true,
+	"/nakama.api.Nakama/ListNotifications":                 true,
+	"/nakama.api.Nakama/ListStorageObjects":                true,
+	"/nakama.api.Nakama/ListSubscriptions":                 true,
+	"/nakama.api.Nakama/ListTournaments":                   true,
+	"/nakama.api.Nakama/ListTournamentRecords":             true,
+	"/nakama.api.Nakama/ListTournamentRecordsAroundOwner":  true,
+	"/nakama.api.Nakama/ListUserGroups":                    true,
+	"/nakama.api.Nakama/ReadStorageObjects":                true,
+}
+
+// RetryPolicy retries failed calls with exponential backoff and jitter.
+type RetryPolicy struct {
+	// MaxAttempts includes the first call, values below 2 disable retries.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter randomizes each backoff by up to this fraction, in [0, 1].
+	Jitter float64
+	// RetryableCodes defaults to Unavailable and ResourceExhausted. Transport errors that never
+	// reached the server are always retryable.
+	RetryableCodes []codes.Code
+	// Methods are full gRPC method names ("/nakama.api.Nakama/GetAccount") to retry, defaults to
+	// idempotent read methods. Listing a method opts in to retrying it even if it has side effects.
+	Methods []string
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+type contextKeyRetryPolicy struct{}
+
+// WithRetryPolicy applies the policy to every idempotent call made through the client.
+func (c *Client) WithRetryPolicy(policy RetryPolicy) *Client {
+	c.retryPolicy = &policy
+	return c
+}
+
+// WithCallRetryPolicy overrides the client retry policy for calls made with the returned context.
+// Like the client policy, it only retries the methods in RetryPolicy.Methods.
+func WithCallRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, contextKeyRetryPolicy{}, &policy)
+}
+
+// WithoutRetry disables retries for calls made with the returned context.
+func WithoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKeyRetryPolicy{}, &RetryPolicy{MaxAttempts: 1})
+}
+
+func (c *Client) retryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	policy, ok := ctx.Value(contextKeyRetryPolicy{}).(*RetryPolicy)
+	if !ok {
+		policy = c.retryPolicy
+	}
+	if policy == nil || policy.MaxAttempts < 2 || !policy.shouldRetryMethod(method) { //nolint:gomnd
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	attemptOpts := append([]grpc.CallOption{}, opts...)
+	var err error
+	for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+		var header metadata.MD
+		err = invoker(ctx, method, req, reply, cc, append(attemptOpts, grpc.Header(&header))...)
+		if err == nil || attempt == policy.MaxAttempts-1 || !policy.isRetryable(ctx, err) {
+			return err
+		}
+
+		wait := policy.backoff(attempt)
+		if retryAfter := parseRetryAfter(c.now(), header.Get(headerRetryAfter)...); retryAfter > wait {
+			wait = min(retryAfter, policy.maxRetryAfter())
+		}
+		if !sleepContext(ctx, wait) {
+			return err
+		}
+	}
+	return err
+}
+
+func (p *RetryPolicy) shouldRetryMethod(method string) bool {
+	if p.Methods == nil {
+		return idempotentMethods[method]
+	}
+	for _, m := range p.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if _, ok := status.FromError(err); !ok {
+		var urlErr *url.Error
+		return errors.As(err, &urlErr)
+	}
+	code := status.Code(err)
+	retryableCodes := p.RetryableCodes
+	if retryableCodes == nil {
+		retryableCodes = []codes.Code{codes.Unavailable, codes.ResourceExhausted}
+	}
+	for _, c := range retryableCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		backoff += backoff * p.Jitter * (rand.Float64()*2 - 1) //nolint:gosec,gomnd
+	}
+	return time.Duration(backoff)
+}
+
+// maxRetryAfter keeps a server from stalling the client for longer than the policy backs off.
+func (p *RetryPolicy) maxRetryAfter() time.Duration {
+	if p.MaxBackoff > 0 {
+		return p.MaxBackoff
+	}
+	return defaultMaxRetryAfter
+}
+
+func parseRetryAfter(now time.Time, values ...string) time.Duration {
+	if len(values) == 0 {
+		return 0
+	}
+	v := values[0]
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return t.Sub(now)
+	}
+	return 0
+}
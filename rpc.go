@@ -0,0 +1,241 @@
+package nakama_client_go
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrRPCTypeMismatch = errors.New("rpc registered with different types")
+
+// RPCCaller is a transport runtime functions can be called over: Session, ServerClient and
+// RealtimeClient.
+type RPCCaller interface {
+	callRPC(ctx context.Context, id string, payload []byte, opts ...CallOption) ([]byte, error)
+}
+
+func (s *Session) callRPC(ctx context.Context, id string, payload []byte, opts ...CallOption) ([]byte, error) {
+	res, err := s.RPC(ctx, id, payload, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(res.Payload), nil
+}
+
+func (s *ServerClient) callRPC(ctx context.Context, id string, payload []byte, opts ...CallOption) ([]byte, error) {
+	return s.RPC(ctx, ServerRPCRequest{ID: id, Payload: payload}, opts...)
+}
+
+// callRPC only honours CallTimeout, the other options do not apply to the socket.
+func (rc *RealtimeClient) callRPC(ctx context.Context, id string, payload []byte, opts ...CallOption) ([]byte, error) {
+	ctx, cancel, _ := applyCallOptions(ctx, opts...)
+	defer cancel()
+	res, err := rc.RPC(ctx, id, string(payload), "")
+	if err != nil {
+		return nil, err
+	}
+	return []byte(res.Payload), nil
+}
+
+// Codec encodes RPC payloads, which nakama carries as strings.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	JSONCodec Codec = jsonCodec{}
+	// ProtobufCodec encodes proto.Message values in base64 encoded wire format.
+	ProtobufCodec Codec = protobufCodec{}
+	// MsgpackCodec encodes values in base64 encoded msgpack.
+	MsgpackCodec Codec = msgpackCodec{}
+	// TextCodec passes string and []byte payloads as is, for functions taking or returning plain
+	// text.
+	TextCodec Codec = textCodec{}
+)
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+type textCodec struct{}
+
+func (textCodec) Marshal(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	}
+	return nil, fmt.Errorf("%T is not a string or []byte", v)
+}
+
+func (textCodec) Unmarshal(data []byte, v any) error {
+	switch v := v.(type) {
+	case *string:
+		*v = string(data)
+		return nil
+	case *[]byte:
+		*v = append([]byte(nil), data...)
+		return nil
+	}
+	return fmt.Errorf("%T is not a *string or *[]byte", v)
+}
+
+type protobufCodec struct{}
+
+func (protobufCodec) Marshal(v any) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a proto.Message", v)
+	}
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return encodeBase64(b), nil
+}
+
+func (protobufCodec) Unmarshal(data []byte, v any) error {
+	b, err := decodeBase64(data)
+	if err != nil {
+		return err
+	}
+	if m, ok := v.(proto.Message); ok {
+		return proto.Unmarshal(b, m)
+	}
+	// v is a pointer to the message pointer, e.g. **api.Rpc
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && rv.Elem().Kind() == reflect.Pointer {
+		elem := rv.Elem()
+		if elem.IsNil() {
+			elem.Set(reflect.New(elem.Type().Elem()))
+		}
+		if m, ok := elem.Interface().(proto.Message); ok {
+			return proto.Unmarshal(b, m)
+		}
+	}
+	return fmt.Errorf("%T is not a proto.Message", v)
+}
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Marshal(v any) ([]byte, error) {
+	b, err := msgpack.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return encodeBase64(b), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v any) error {
+	b, err := decodeBase64(data)
+	if err != nil {
+		return err
+	}
+	return msgpack.Unmarshal(b, v)
+}
+
+func encodeBase64(b []byte) []byte {
+	out := make([]byte, base64.StdEncoding.EncodedLen(len(b)))
+	base64.StdEncoding.Encode(out, b)
+	return out
+}
+
+func decodeBase64(data []byte) ([]byte, error) {
+	out := make([]byte, base64.StdEncoding.DecodedLen(len(data)))
+	n, err := base64.StdEncoding.Decode(out, data)
+	if err != nil {
+		return nil, err
+	}
+	return out[:n], nil
+}
+
+// RPC binds a runtime function ID to its request and response types.
+type RPC[Req, Resp any] struct {
+	ID    string
+	Codec Codec
+}
+
+// NewRPC defines a runtime function, codec defaults to JSONCodec.
+func NewRPC[Req, Resp any](id string, codec Codec) RPC[Req, Resp] {
+	if codec == nil {
+		codec = JSONCodec
+	}
+	return RPC[Req, Resp]{ID: id, Codec: codec}
+}
+
+// Call encodes req, calls the function and decodes its response. An empty response payload
+// decodes to the zero value.
+func (r RPC[Req, Resp]) Call(ctx context.Context, caller RPCCaller, req Req, opts ...CallOption) (Resp, error) {
+	var resp Resp
+	payload, err := r.Codec.Marshal(req)
+	if err != nil {
+		return resp, fmt.Errorf("encode rpc %s request: %w", r.ID, err)
+	}
+	res, err := caller.callRPC(ctx, r.ID, payload, opts...)
+	if err != nil {
+		return resp, err
+	}
+	if len(res) == 0 {
+		return resp, nil
+	}
+	if err := r.Codec.Unmarshal(res, &resp); err != nil {
+		return resp, fmt.Errorf("decode rpc %s response: %w", r.ID, err)
+	}
+	return resp, nil
+}
+
+// CallRPC calls a runtime function with JSON encoded payloads.
+func CallRPC[Req, Resp any](ctx context.Context, caller RPCCaller, id string, req Req, opts ...CallOption) (Resp, error) {
+	return NewRPC[Req, Resp](id, JSONCodec).Call(ctx, caller, req, opts...)
+}
+
+// RPCRegistry makes sure every runtime function ID is defined with a single pair of types, so
+// definitions shared across packages can not drift apart.
+type RPCRegistry struct {
+	mu   *sync.Mutex
+	rpcs map[string][2]reflect.Type
+}
+
+func NewRPCRegistry() *RPCRegistry {
+	return &RPCRegistry{
+		mu:   &sync.Mutex{},
+		rpcs: map[string][2]reflect.Type{},
+	}
+}
+
+// RegisterRPC defines a runtime function in the registry, it returns an error matching
+// ErrRPCTypeMismatch if id is already registered with different types.
+func RegisterRPC[Req, Resp any](registry *RPCRegistry, id string, codec Codec) (RPC[Req, Resp], error) {
+	types := [2]reflect.Type{reflect.TypeOf((*Req)(nil)).Elem(), reflect.TypeOf((*Resp)(nil)).Elem()}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if registered, ok := registry.rpcs[id]; ok && registered != types {
+		return RPC[Req, Resp]{}, fmt.Errorf("%w: rpc %s already registered as %s -> %s", ErrRPCTypeMismatch, id, registered[0], registered[1])
+	}
+	registry.rpcs[id] = types
+	return NewRPC[Req, Resp](id, codec), nil
+}
+
+// Lookup returns the request and response types registered for id.
+func (r *RPCRegistry) Lookup(id string) (req, resp reflect.Type, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	types, ok := r.rpcs[id]
+	return types[0], types[1], ok
+}
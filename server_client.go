@@ -0,0 +1,164 @@
+package nakama_client_go
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/joesonw/nakama-client-go/httputil"
+)
+
+const (
+	queryMetadataPrefix = "q_"
+	queryUserID         = "user_id"
+	queryUnwrap         = "unwrap"
+	methodRpcFunc       = "/nakama.api.Nakama/RpcFunc"
+)
+
+// ServerClient calls the endpoints authorized by the runtime http_key, for server to server use.
+type ServerClient struct {
+	client  *Client
+	httpKey string
+}
+
+func (c *Client) NewServerClient(httpKey string) *ServerClient {
+	return &ServerClient{
+		client:  c,
+		httpKey: httpKey,
+	}
+}
+
+type ServerRPCRequest struct {
+	ID      string
+	Payload []byte
+	// Method is http.MethodPost (default) or http.MethodGet, GET requests can not carry a payload.
+	// Only the HTTP transport tells them apart.
+	Method string
+	// Unwrap sends the payload as the raw request body and reads the raw response body, instead of
+	// JSON encoded strings. It is only supported by the HTTP transport, other transports return
+	// ErrInvalidArgument.
+	Unwrap bool
+	// UserID is passed to the runtime as the user_id query parameter, for functions acting on
+	// behalf of a user. It is ignored over gRPC, where the runtime takes the user from the auth
+	// context.
+	UserID string
+	// Query parameters are passed to the runtime, over gRPC as q_ metadata with lower-cased names.
+	Query url.Values
+}
+
+// RPC calls a runtime function and returns its payload.
+func (s *ServerClient) RPC(ctx context.Context, req ServerRPCRequest, opts ...CallOption) ([]byte, error) {
+	method := req.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	if method != http.MethodPost && method != http.MethodGet {
+		return nil, wrapError(status.Errorf(codes.InvalidArgument, "unsupported rpc method %q", method))
+	}
+	if method == http.MethodGet && len(req.Payload) > 0 {
+		return nil, wrapError(status.Error(codes.InvalidArgument, "GET rpc can not carry a payload"))
+	}
+	httpAPI, isHTTP := s.client.api.(*httpClient)
+	if req.Unwrap && !isHTTP {
+		return nil, wrapError(status.Error(codes.InvalidArgument, "unwrapped rpc requires the http transport"))
+	}
+
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	ctx = httputil.WithHTTPKeyAuth(ctx, s.httpKey)
+
+	query := url.Values{}
+	for k, vv := range req.Query {
+		query[k] = vv
+	}
+	if req.UserID != "" {
+		query.Set(queryUserID, req.UserID)
+	}
+	if len(query) > 0 {
+		md := metadata.MD{}
+		for k, vv := range query {
+			md.Append(queryMetadataPrefix+k, vv...)
+		}
+		existing, _ := metadata.FromOutgoingContext(ctx)
+		ctx = metadata.NewOutgoingContext(ctx, metadata.Join(existing, md))
+	}
+
+	// the gRPC server reads the http_key from the message, the HTTP transport sends it as a query
+	// parameter
+	rpc := &api.Rpc{Id: req.ID, Payload: string(req.Payload), HttpKey: s.httpKey}
+	var res *api.Rpc
+	var err error
+	if req.Unwrap {
+		res, err = httpAPI.unwrappedRPC(ctx, method, rpc, callOpts...)
+	} else if method == http.MethodGet {
+		res, err = s.client.api.RpcFunc(ctx, rpc, callOpts...)
+	} else {
+		res, err = s.client.api.RpcFunc2(ctx, rpc, callOpts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(res.Payload), nil
+}
+
+// unwrappedRPC is not part of the generated client, grpc-gateway can not describe raw bodies.
+func (c *httpClient) unwrappedRPC(ctx context.Context, httpMethod string, req *api.Rpc, opts ...grpc.CallOption) (*api.Rpc, error) {
+	res := &api.Rpc{}
+	invoker := func(ctx context.Context, method string, req, res interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.doUnwrappedRPC(ctx, httpMethod, req.(*api.Rpc), res.(*api.Rpc), opts...)
+	}
+	if err := c.Interceptor(ctx, methodRpcFunc, req, res, nil, invoker, opts...); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *httpClient) doUnwrappedRPC(ctx context.Context, httpMethod string, req, res *api.Rpc, opts ...grpc.CallOption) error {
+	u, err := url.Parse(c.URL + "/v2/rpc/" + url.PathEscape(req.Id))
+	if err != nil {
+		return err
+	}
+	u.RawQuery = url.Values{queryUnwrap: []string{""}}.Encode()
+
+	var body io.Reader
+	if httpMethod == http.MethodPost {
+		body = bytes.NewReader([]byte(req.Payload))
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, httpMethod, u.String(), body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	httpReq, err = httputil.AttachGRPCToRequest(ctx, httpReq, opts...)
+	if err != nil {
+		return err
+	}
+
+	httpRes, err := c.Client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httputil.RetrieveGRPCFromResponse(ctx, httpRes, opts...)
+	if err := httputil.CheckResponse(ctx, httpRes); err != nil {
+		_ = httpRes.Body.Close()
+		return err
+	}
+	resBytes, err := io.ReadAll(httpRes.Body)
+	_ = httpRes.Body.Close()
+	if err != nil {
+		return err
+	}
+	res.Id = req.Id
+	res.Payload = string(resBytes)
+	return nil
+}
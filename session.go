@@ -2,838 +2,477 @@ package nakama_client_go
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/heroiclabs/nakama-common/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/joesonw/nakama-client-go/httputil"
 )
 
+const backgroundRefreshRetryInterval = 10 * time.Second
+
+type contextKeySession struct{}
+
 type Session struct {
+	mu                 *sync.RWMutex
 	apiSession         *api.Session
 	client             *Client
+	claims             SessionClaims
 	expiresAt          time.Time
 	autoRefreshSession bool
-	vars               map[string]string
-	userID             string
-	username           string
 	refreshExpiresAt   time.Time
+
+	refreshing       *sessionRefreshCall
+	onRefresh        func(*Session)
+	onRefreshFailed  func(err error)
+	onRefreshExpired func()
+}
+
+type sessionRefreshCall struct {
+	done chan struct{}
+	err  error
 }
 
 func newSession(apiSession *api.Session, client *Client) (*Session, error) {
 	s := &Session{
-		apiSession:         apiSession,
+		mu:                 &sync.RWMutex{},
 		client:             client,
 		autoRefreshSession: true,
-		vars:               map[string]string{},
 	}
-	if err := s.decode(); err != nil {
+	if err := s.update(apiSession); err != nil {
 		return nil, err
 	}
 	return s, nil
 }
 
-func (s *Session) decode() error {
-	var token struct {
-		Vrs      map[string]string `json:"vrs"`
-		UID      string            `json:"uid"`
-		Username string            `json:"usn"`
-		Exp      int64             `json:"exp"`
-	}
-	var refreshToken struct {
-		Exp int64 `json:"exp"`
+// update decodes the claims of apiSession and replaces the current tokens, s.mu must be held
+// by the caller once the session is shared.
+func (s *Session) update(apiSession *api.Session) error {
+	if key := s.client.sessionVerificationKey; key != nil {
+		if err := verifyJWTSignature(apiSession.Token, key); err != nil {
+			return err
+		}
 	}
-	if err := unmarshalJWTBody(s.apiSession.Token, &token); err != nil {
+
+	var token SessionClaims
+	var refreshToken SessionClaims
+	if err := unmarshalJWTBody(apiSession.Token, &token); err != nil {
 		return err
 	}
-	if err := unmarshalJWTBody(s.apiSession.RefreshToken, &refreshToken); err != nil {
-		return err
+	refreshToken.ExpiresAt = token.ExpiresAt
+	if apiSession.RefreshToken != "" {
+		if err := unmarshalJWTBody(apiSession.RefreshToken, &refreshToken); err != nil {
+			return err
+		}
 	}
-	s.vars = token.Vrs
-	s.userID = token.UID
-	s.username = token.Username
-	s.expiresAt = time.Unix(token.Exp, 0)
-	s.refreshExpiresAt = time.Unix(refreshToken.Exp, 0)
+	s.apiSession = apiSession
+	s.claims = token
+	s.expiresAt = time.Unix(token.ExpiresAt, 0)
+	s.refreshExpiresAt = time.Unix(refreshToken.ExpiresAt, 0)
 	return nil
 }
 
-func (s *Session) Token() string {
-	return s.apiSession.GetToken()
-}
-
-func (s *Session) Var(name string) string {
-	return s.vars[name]
-}
-
-func (s *Session) IsExpired() bool {
-	return s.expiresAt.Before(time.Now())
-}
-
-func (s *Session) IsRefreshExpired() bool {
-	return s.refreshExpiresAt.Before(time.Now())
-}
-
-func (s *Session) UserID() string {
-	return s.userID
-}
-
-func (s *Session) Username() string {
-	return s.username
+// SessionClaims are the claims nakama puts in session tokens.
+type SessionClaims struct {
+	TokenID   string            `json:"tid"`
+	UserID    string            `json:"uid"`
+	Username  string            `json:"usn"`
+	Vars      map[string]string `json:"vrs"`
+	ExpiresAt int64             `json:"exp"`
+	IssuedAt  int64             `json:"iat"`
 }
 
-func (s *Session) DisableAutoRefresh() *Session {
-	s.autoRefreshSession = false
-	return s
+// SessionData is the persistable form of a Session, see Client.RestoreSessionData.
+type SessionData struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Created      bool   `json:"created,omitempty"`
 }
 
-func (s *Session) getUpToDatedToken(ctx context.Context) (*api.Session, error) {
-	if s.IsRefreshExpired() {
-		return nil, fmt.Errorf("refresh token expired")
-	}
-	if s.autoRefreshSession && s.apiSession.RefreshToken != "" && s.IsExpired() {
-		if err := s.Refresh(ctx); err != nil {
-			return nil, err
-		}
+func (s *Session) Data() SessionData {
+	token := s.current()
+	return SessionData{
+		Token:        token.Token,
+		RefreshToken: token.RefreshToken,
+		Created:      token.Created,
 	}
-	return s.apiSession, nil
 }
 
-func (s *Session) attachSessionContext(ctx context.Context) (context.Context, error) {
-	token, err := s.getUpToDatedToken(ctx)
-	if err != nil {
-		return nil, err
-	}
-	return httputil.WithBearerJWT(ctx, token.Token), nil
+func (s *Session) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Data())
 }
 
-func (s *Session) Logout(ctx context.Context) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.SessionLogout(ctx, &api.SessionLogoutRequest{
-		Token:        s.apiSession.Token,
-		RefreshToken: s.apiSession.RefreshToken,
-	})
-	return err
+func (s *Session) current() *api.Session {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.apiSession
 }
 
-func (s *Session) Refresh(ctx context.Context) error {
-	res, err := s.client.api.SessionRefresh(httputil.WithBasicAuth(ctx, s.client.serverKey, ""), &api.SessionRefreshRequest{
-		Token: s.apiSession.RefreshToken,
-	})
-	if err != nil {
-		return err
-	}
-	s.apiSession = res
-	return err
+func (s *Session) Token() string {
+	return s.current().GetToken()
 }
 
-func (s *Session) AddGroupUsers(ctx context.Context, req *api.AddGroupUsersRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.AddGroupUsers(ctx, req)
-	return err
+func (s *Session) RefreshToken() string {
+	return s.current().GetRefreshToken()
 }
 
-func (s *Session) AddFriends(ctx context.Context, req *api.AddFriendsRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.AddFriends(ctx, req)
-	return err
+func (s *Session) Var(name string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.claims.Vars[name]
 }
 
-func (s *Session) BanGroupUsers(ctx context.Context, req *api.BanGroupUsersRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.BanGroupUsers(ctx, req)
-	return err
+func (s *Session) Vars() map[string]string {
+	return s.Claims().Vars
 }
 
-func (s *Session) BlockFriends(ctx context.Context, req *api.BlockFriendsRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
+// VarAs decodes a session variable as JSON into T, strings are returned as is.
+func VarAs[T any](s *Session, name string) (T, error) {
+	var v T
+	raw, ok := s.Vars()[name]
+	if !ok {
+		return v, fmt.Errorf("session var %q: %w", name, ErrNotFound)
 	}
-
-	_, err = s.client.api.BlockFriends(ctx, req)
-	return err
-}
-
-func (s *Session) CreateGroup(ctx context.Context, req *api.CreateGroupRequest) (*api.Group, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
+	if p, ok := any(&v).(*string); ok {
+		*p = raw
+		return v, nil
 	}
-
-	res, err := s.client.api.CreateGroup(ctx, req)
-	if err != nil {
-		return nil, err
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		return v, fmt.Errorf("session var %q: %w", name, err)
 	}
-
-	return res, nil
+	return v, nil
 }
 
-func (s *Session) DeleteFriends(ctx context.Context, req *api.DeleteFriendsRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
+func (s *Session) Claims() SessionClaims {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	claims := s.claims
+	claims.Vars = make(map[string]string, len(s.claims.Vars))
+	for k, v := range s.claims.Vars {
+		claims.Vars[k] = v
 	}
-
-	_, err = s.client.api.DeleteFriends(ctx, req)
-	return err
+	return claims
 }
 
-func (s *Session) DeleteGroup(ctx context.Context, req *api.DeleteGroupRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.DeleteGroup(ctx, req)
-	return err
+func (s *Session) TokenID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.claims.TokenID
 }
 
-func (s *Session) DeleteLeaderboardRecord(ctx context.Context, req *api.DeleteLeaderboardRecordRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
+// IssuedAt is zero if the token carries no iat claim, which nakama does not set.
+func (s *Session) IssuedAt() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.claims.IssuedAt == 0 {
+		return time.Time{}
 	}
-
-	_, err = s.client.api.DeleteLeaderboardRecord(ctx, req)
-	return err
+	return time.Unix(s.claims.IssuedAt, 0)
 }
 
-func (s *Session) DeleteNotifications(ctx context.Context, req *api.DeleteNotificationsRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.DeleteNotifications(ctx, req)
-	return err
+func (s *Session) ExpiresAt() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.expiresAt
 }
 
-func (s *Session) DeleteStorageObjects(ctx context.Context, req *api.DeleteStorageObjectsRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.DeleteStorageObjects(ctx, req)
-	return err
+func (s *Session) RefreshExpiresAt() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.refreshExpiresAt
 }
 
-func (s *Session) DemoteGroupUsers(ctx context.Context, req *api.DemoteGroupUsersRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.DemoteGroupUsers(ctx, req)
-	return err
+func (s *Session) IsExpired() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.expiresAt.Before(s.client.now())
 }
 
-func (s *Session) EmitEvent(ctx context.Context, req *api.Event) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.Event(ctx, req)
-	return err
+func (s *Session) IsRefreshExpired() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.refreshExpiresAt.Before(s.client.now())
 }
 
-func (s *Session) GetAccount(ctx context.Context) (*api.Account, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.GetAccount(ctx, &emptypb.Empty{})
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
+func (s *Session) UserID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.claims.UserID
 }
 
-func (s *Session) GetUsers(ctx context.Context, req *api.GetUsersRequest) ([]*api.User, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.GetUsers(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res.GetUsers(), nil
+func (s *Session) Username() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.claims.Username
 }
 
-func (s *Session) JoinGroup(ctx context.Context, req *api.JoinGroupRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.JoinGroup(ctx, req)
-	return err
+func (s *Session) DisableAutoRefresh() *Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.autoRefreshSession = false
+	return s
 }
 
-func (s *Session) JoinTournament(ctx context.Context, req *api.JoinTournamentRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.JoinTournament(ctx, req)
-	return err
+func (s *Session) autoRefresh() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.autoRefreshSession
 }
 
-func (s *Session) KickGroupUsers(ctx context.Context, req *api.KickGroupUsersRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.KickGroupUsers(ctx, req)
-	return err
+// OnRefreshFailed is called whenever refreshing the session fails, the application should
+// authenticate again to get a new session.
+func (s *Session) OnRefreshFailed(f func(err error)) *Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRefreshFailed = f
+	return s
 }
 
-func (s *Session) LeaveGroup(ctx context.Context, req *api.LeaveGroupRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.LeaveGroup(ctx, req)
-	return err
+// OnRefresh is called after every successful refresh, e.g. to persist the new tokens or to
+// reconnect sockets before the token they were opened with expires.
+func (s *Session) OnRefresh(f func(*Session)) *Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRefresh = f
+	return s
 }
 
-func (s *Session) ListChannelMessages(ctx context.Context, req *api.ListChannelMessagesRequest) (*api.ChannelMessageList, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ListChannelMessages(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
+// OnRefreshExpired is called when the refresh token is found expired, the application must
+// authenticate again.
+func (s *Session) OnRefreshExpired(f func()) *Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRefreshExpired = f
+	return s
 }
 
-func (s *Session) ListGroupUsers(ctx context.Context, req *api.ListGroupUsersRequest) (*api.GroupUserList, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ListGroupUsers(ctx, req)
-	if err != nil {
-		return nil, err
+func (s *Session) refreshExpired() error {
+	s.mu.RLock()
+	onRefreshExpired := s.onRefreshExpired
+	s.mu.RUnlock()
+	if onRefreshExpired != nil {
+		onRefreshExpired()
 	}
-
-	return res, nil
+	return fmt.Errorf("refresh token expired: %w", ErrSessionExpired)
 }
 
-func (s *Session) ListUserGroups(ctx context.Context, req *api.ListUserGroupsRequest) (*api.UserGroupList, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
+func (s *Session) getUpToDatedToken(ctx context.Context) (*api.Session, error) {
+	if s.IsRefreshExpired() {
+		return nil, s.refreshExpired()
 	}
-
-	res, err := s.client.api.ListUserGroups(ctx, req)
-	if err != nil {
-		return nil, err
+	token := s.current()
+	if s.autoRefresh() && token.RefreshToken != "" && s.IsExpired() {
+		if err := s.refresh(ctx, token.Token); err != nil {
+			return nil, err
+		}
 	}
-
-	return res, nil
+	return s.current(), nil
 }
 
-func (s *Session) ListGroups(ctx context.Context, req *api.ListGroupsRequest) (*api.GroupList, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ListGroups(ctx, req)
+func (s *Session) attachSessionContext(ctx context.Context) (context.Context, error) {
+	token, err := s.getUpToDatedToken(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	return res, nil
-}
-
-func (s *Session) LinkApple(ctx context.Context, req *api.AccountApple) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.LinkApple(ctx, req)
-	return err
-}
-
-func (s *Session) LinkCustom(ctx context.Context, req *api.AccountCustom) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.LinkCustom(ctx, req)
-	return err
-}
-
-func (s *Session) LinkDevice(ctx context.Context, req *api.AccountDevice) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.LinkDevice(ctx, req)
-	return err
-}
-
-func (s *Session) LinkEmail(ctx context.Context, req *api.AccountEmail) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.LinkEmail(ctx, req)
-	return err
-}
-
-func (s *Session) LinkFacebook(ctx context.Context, req *api.LinkFacebookRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.LinkFacebook(ctx, req)
-	return err
-}
-
-func (s *Session) LinkFacebookInstantGame(ctx context.Context, req *api.AccountFacebookInstantGame) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.LinkFacebookInstantGame(ctx, req)
-	return err
+	ctx = context.WithValue(ctx, contextKeySession{}, s)
+	return httputil.WithBearerJWT(ctx, token.Token), nil
 }
 
-func (s *Session) LinkGameCenter(ctx context.Context, req *api.AccountGameCenter) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
+// sessionInterceptor refreshes the session once and replays the call when the server rejects
+// a token that has not expired locally, e.g. after it was revoked or with skewed clocks.
+func sessionInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err == nil || status.Code(err) != codes.Unauthenticated {
 		return err
 	}
-
-	_, err = s.client.api.LinkGameCenter(ctx, req)
-	return err
-}
-
-func (s *Session) LinkGoogle(ctx context.Context, req *api.AccountGoogle) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
+	s, _ := ctx.Value(contextKeySession{}).(*Session)
+	if s == nil || !s.autoRefresh() || s.RefreshToken() == "" {
 		return err
 	}
-
-	_, err = s.client.api.LinkGoogle(ctx, req)
-	return err
-}
-
-func (s *Session) LinkSteam(ctx context.Context, req *api.LinkSteamRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
+	if err := s.refresh(ctx, httputil.GetBearerJWT(ctx)); err != nil {
 		return err
 	}
-
-	_, err = s.client.api.LinkSteam(ctx, req)
-	return err
-}
-
-func (s *Session) ListFriends(ctx context.Context, req *api.ListFriendsRequest) (*api.FriendList, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ListFriends(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) ListLeaderboardRecords(ctx context.Context, req *api.ListLeaderboardRecordsRequest) (*api.LeaderboardRecordList, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ListLeaderboardRecords(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) ListLeaderboardRecordsAroundOwner(ctx context.Context, req *api.ListLeaderboardRecordsAroundOwnerRequest) (*api.LeaderboardRecordList, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ListLeaderboardRecordsAroundOwner(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) ListMatches(ctx context.Context, req *api.ListMatchesRequest) (*api.MatchList, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ListMatches(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) ListNotifications(ctx context.Context, req *api.ListNotificationsRequest) (*api.NotificationList, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ListNotifications(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) ListStorageObjects(ctx context.Context, req *api.ListStorageObjectsRequest) (*api.StorageObjectList, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ListStorageObjects(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) ListTournaments(ctx context.Context, req *api.ListTournamentsRequest) (*api.TournamentList, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ListTournaments(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) ListTournamentRecords(ctx context.Context, req *api.ListTournamentRecordsRequest) (*api.TournamentRecordList, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ListTournamentRecords(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) ListTournamentRecordsAroundOwner(ctx context.Context, req *api.ListTournamentRecordsAroundOwnerRequest) (*api.TournamentRecordList, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ListTournamentRecordsAroundOwner(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
+	return invoker(httputil.WithBearerJWT(ctx, s.Token()), method, req, reply, cc, opts...)
 }
 
-func (s *Session) PromoteGroupUsers(ctx context.Context, req *api.PromoteGroupUsersRequest) error {
+func (s *Session) Logout(ctx context.Context, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
 	ctx, err := s.attachSessionContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	_, err = s.client.api.PromoteGroupUsers(ctx, req)
-	return err
-}
-
-func (s *Session) ReadStorageObjects(ctx context.Context, req *api.ReadStorageObjectsRequest) (*api.StorageObjects, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ReadStorageObjects(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) RPC(ctx context.Context, id string, payload []byte) (*api.Rpc, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.RpcFunc(ctx, &api.Rpc{Id: id, Payload: string(payload)})
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) UnlinkApple(ctx context.Context, req *api.AccountApple) error {
-	ctx, err := s.attachSessionContext(ctx)
+	token := s.current()
+	_, err = s.client.api.SessionLogout(ctx, &api.SessionLogoutRequest{
+		Token:        token.Token,
+		RefreshToken: token.RefreshToken,
+	}, callOpts...)
 	if err != nil {
 		return err
 	}
-
-	_, err = s.client.api.UnlinkApple(ctx, req)
-	return err
+	return s.deleteSaved(ctx)
 }
 
-func (s *Session) UnlinkCustom(ctx context.Context, req *api.AccountCustom) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.UnlinkCustom(ctx, req)
-	return err
+// Refresh exchanges the refresh token for a new session. Concurrent calls share a single request,
+// made with the options of the first caller.
+func (s *Session) Refresh(ctx context.Context, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
+	return s.refresh(ctx, "", callOpts...)
 }
 
-func (s *Session) UnlinkDevice(ctx context.Context, req *api.AccountDevice) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
+// refresh is a no-op if staleToken is set and has already been replaced, so that callers
+// racing on the same expired token cause a single refresh.
+func (s *Session) refresh(ctx context.Context, staleToken string, opts ...grpc.CallOption) error {
+	s.mu.Lock()
+	if staleToken != "" && s.apiSession.Token != staleToken {
+		s.mu.Unlock()
+		return nil
 	}
-
-	_, err = s.client.api.UnlinkDevice(ctx, req)
-	return err
-}
-
-func (s *Session) UnlinkEmail(ctx context.Context, req *api.AccountEmail) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
+	if call := s.refreshing; call != nil {
+		s.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-call.done:
+			return call.err
+		}
 	}
+	call := &sessionRefreshCall{done: make(chan struct{})}
+	s.refreshing = call
+	refreshToken := s.apiSession.RefreshToken
+	s.mu.Unlock()
 
-	_, err = s.client.api.UnlinkEmail(ctx, req)
-	return err
-}
+	res, err := s.client.api.SessionRefresh(httputil.WithBasicAuth(context.WithValue(ctx, contextKeySession{}, (*Session)(nil)), s.client.serverKey, ""), &api.SessionRefreshRequest{
+		Token: refreshToken,
+	}, opts...)
 
-func (s *Session) UnlinkFacebook(ctx context.Context, req *api.AccountFacebook) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
+	s.mu.Lock()
+	if err == nil {
+		if res.RefreshToken == "" {
+			res.RefreshToken = refreshToken
+		}
+		err = s.update(res)
+		if err == nil && s.claims.IssuedAt != 0 {
+			s.client.observeServerTime(time.Unix(s.claims.IssuedAt, 0))
+		}
 	}
+	s.refreshing = nil
+	call.err = err
+	onRefresh, onRefreshFailed := s.onRefresh, s.onRefreshFailed
+	s.mu.Unlock()
+	close(call.done)
 
-	_, err = s.client.api.UnlinkFacebook(ctx, req)
-	return err
-}
-
-func (s *Session) UnlinkFacebookInstantGame(ctx context.Context, req *api.AccountFacebookInstantGame) error {
-	ctx, err := s.attachSessionContext(ctx)
 	if err != nil {
+		if onRefreshFailed != nil {
+			onRefreshFailed(err)
+		}
 		return err
 	}
-
-	_, err = s.client.api.UnlinkFacebookInstantGame(ctx, req)
-	return err
-}
-
-func (s *Session) UnlinkGameCenter(ctx context.Context, req *api.AccountGameCenter) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
+	if onRefresh != nil {
+		onRefresh(s)
 	}
-
-	_, err = s.client.api.UnlinkGameCenter(ctx, req)
-	return err
+	// the session is usable even if it could not be persisted
+	s.save(ctx)
+	return nil
 }
 
-func (s *Session) UnlinkGoogle(ctx context.Context, req *api.AccountGoogle) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
+// RefreshInBackground refreshes the session margin before the token expires, until the returned
+// function is called or the refresh token expires. Failed refreshes are retried, unless the
+// server rejected the refresh token.
+func (s *Session) RefreshInBackground(margin time.Duration) (func(), error) {
+	if s.IsRefreshExpired() {
+		return nil, s.refreshExpired()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		refreshed := false
+		for {
+			s.mu.RLock()
+			token := s.apiSession.Token
+			lifetime := s.expiresAt.Sub(s.client.now())
+			s.mu.RUnlock()
+
+			wait := lifetime - margin
+			if wait <= 0 && refreshed {
+				// margin is longer than the token lifetime, avoid refreshing in a loop
+				wait = lifetime / 2 //nolint:gomnd
+			}
+
+			if !sleepContext(ctx, wait) {
+				return
+			}
+			if s.IsRefreshExpired() {
+				_ = s.refreshExpired()
+				return
+			}
+
+			for {
+				err := s.refresh(ctx, token)
+				if err == nil || ctx.Err() != nil {
+					refreshed = true
+					break
+				}
+				if status.Code(err) == codes.Unauthenticated {
+					return
+				}
+				if !sleepContext(ctx, backgroundRefreshRetryInterval) {
+					return
+				}
+			}
+		}
+	}()
 
-	_, err = s.client.api.UnlinkGoogle(ctx, req)
-	return err
+	return cancel, nil
 }
 
-func (s *Session) UnlinkSteam(ctx context.Context, req *api.AccountSteam) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
 	}
-
-	_, err = s.client.api.UnlinkSteam(ctx, req)
-	return err
 }
 
-func (s *Session) UpdateAccount(ctx context.Context, req *api.UpdateAccountRequest) error {
+// DeleteAccount deletes the user account, the session can not be used afterwards.
+func (s *Session) DeleteAccount(ctx context.Context, opts ...CallOption) error {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
 	ctx, err := s.attachSessionContext(ctx)
 	if err != nil {
 		return err
 	}
 
-	_, err = s.client.api.UpdateAccount(ctx, req)
-	return err
-}
-
-func (s *Session) UpdateGroup(ctx context.Context, req *api.UpdateGroupRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
+	if _, err = s.client.api.DeleteAccount(ctx, &emptypb.Empty{}, callOpts...); err != nil {
 		return err
 	}
-
-	_, err = s.client.api.UpdateGroup(ctx, req)
-	return err
+	return s.deleteSaved(ctx)
 }
 
-func (s *Session) ValidatePurchaseApple(ctx context.Context, req *api.ValidatePurchaseAppleRequest) (*api.ValidatePurchaseResponse, error) {
+func (s *Session) RPC(ctx context.Context, id string, payload []byte, opts ...CallOption) (*api.Rpc, error) {
+	ctx, cancel, callOpts := applyCallOptions(ctx, opts...)
+	defer cancel()
 	ctx, err := s.attachSessionContext(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := s.client.api.ValidatePurchaseApple(ctx, req)
+	res, err := s.client.api.RpcFunc(ctx, &api.Rpc{Id: id, Payload: string(payload)}, callOpts...)
 	if err != nil {
 		return nil, err
 	}
 
 	return res, nil
 }
-
-func (s *Session) ValidatePurchaseGoogle(ctx context.Context, req *api.ValidatePurchaseGoogleRequest) (*api.ValidatePurchaseResponse, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ValidatePurchaseGoogle(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) ValidatePurchaseHuawei(ctx context.Context, req *api.ValidatePurchaseHuaweiRequest) (*api.ValidatePurchaseResponse, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.ValidatePurchaseHuawei(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) WriteLeaderboardRecord(ctx context.Context, req *api.WriteLeaderboardRecordRequest) (*api.LeaderboardRecord, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.WriteLeaderboardRecord(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) WriteStorageObjects(ctx context.Context, req *api.WriteStorageObjectsRequest) (*api.StorageObjectAcks, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.WriteStorageObjects(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) WriteTournamentRecord(ctx context.Context, req *api.WriteTournamentRecordRequest) (*api.LeaderboardRecord, error) {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	res, err := s.client.api.WriteTournamentRecord(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func (s *Session) ImportSteamFriends(ctx context.Context, req *api.ImportSteamFriendsRequest) error {
-	ctx, err := s.attachSessionContext(ctx)
-	if err != nil {
-		return err
-	}
-
-	_, err = s.client.api.ImportSteamFriends(ctx, req)
-	return err
-}
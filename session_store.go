@@ -0,0 +1,286 @@
+package nakama_client_go
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	sessionFileExt  = ".json"
+	sessionFileMode = 0o600
+	sessionDirMode  = 0o700
+
+	scryptSaltSize = 16
+	scryptN        = 1 << 15
+	scryptR        = 8
+	scryptP        = 1
+	aesKeySize     = 32
+)
+
+// SessionStore persists sessions, keyed by a profile name or the user ID. Load returns
+// ErrNotFound when there is no session stored under key.
+type SessionStore interface {
+	Load(ctx context.Context, key string) (*SessionData, error)
+	Save(ctx context.Context, key string, data SessionData) error
+	Delete(ctx context.Context, key string) error
+}
+
+// WithSessionStore saves sessions to store after authentication and every refresh, and deletes
+// them on logout. Sessions are stored under profile, or under the user ID if profile is empty.
+func (c *Client) WithSessionStore(store SessionStore, profile string) *Client {
+	c.sessionStore = store
+	c.sessionProfile = profile
+	return c
+}
+
+// LoadSession restores a session from the session store, key defaults to the client profile.
+func (c *Client) LoadSession(ctx context.Context, key string) (*Session, error) {
+	if c.sessionStore == nil {
+		return nil, errors.New("no session store")
+	}
+	if key == "" {
+		key = c.sessionProfile
+	}
+	data, err := c.sessionStore.Load(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return c.RestoreSessionData(*data)
+}
+
+func (s *Session) storeKey() string {
+	if s.client.sessionProfile != "" {
+		return s.client.sessionProfile
+	}
+	return s.UserID()
+}
+
+// OnSessionSaveFailed is called when a session could not be saved to the session store, the
+// session itself stays usable.
+func (c *Client) OnSessionSaveFailed(f func(sess *Session, err error)) *Client {
+	c.onSessionSaveFailed = f
+	return c
+}
+
+func (s *Session) save(ctx context.Context) {
+	if s.client.sessionStore == nil {
+		return
+	}
+	err := s.client.sessionStore.Save(ctx, s.storeKey(), s.Data())
+	if err != nil && s.client.onSessionSaveFailed != nil {
+		s.client.onSessionSaveFailed(s, fmt.Errorf("save session: %w", err))
+	}
+}
+
+func (s *Session) deleteSaved(ctx context.Context) error {
+	if s.client.sessionStore == nil {
+		return nil
+	}
+	if err := s.client.sessionStore.Delete(ctx, s.storeKey()); err != nil {
+		return fmt.Errorf("delete session: %w", err)
+	}
+	return nil
+}
+
+type MemorySessionStore struct {
+	mu       *sync.Mutex
+	sessions map[string]SessionData
+}
+
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{
+		mu:       &sync.Mutex{},
+		sessions: map[string]SessionData{},
+	}
+}
+
+func (m *MemorySessionStore) Load(ctx context.Context, key string) (*SessionData, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.sessions[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &data, nil
+}
+
+func (m *MemorySessionStore) Save(ctx context.Context, key string, data SessionData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[key] = data
+	return nil
+}
+
+func (m *MemorySessionStore) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, key)
+	return nil
+}
+
+// FileSessionStore keeps one JSON file per key in a directory, optionally encrypted.
+type FileSessionStore struct {
+	mu         *sync.Mutex
+	dir        string
+	passphrase []byte
+}
+
+func NewFileSessionStore(dir string) *FileSessionStore {
+	return &FileSessionStore{
+		mu:  &sync.Mutex{},
+		dir: dir,
+	}
+}
+
+// NewEncryptedFileSessionStore encrypts every file with AES-GCM, under a key derived from
+// passphrase with scrypt and a random per-file salt.
+func NewEncryptedFileSessionStore(dir, passphrase string) *FileSessionStore {
+	return &FileSessionStore{
+		mu:         &sync.Mutex{},
+		dir:        dir,
+		passphrase: []byte(passphrase),
+	}
+}
+
+func (f *FileSessionStore) path(key string) string {
+	return filepath.Join(f.dir, url.PathEscape(key)+sessionFileExt)
+}
+
+func (f *FileSessionStore) Load(ctx context.Context, key string) (*SessionData, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	b, err := os.ReadFile(f.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if f.passphrase != nil {
+		if b, err = f.decrypt(b); err != nil {
+			return nil, err
+		}
+	}
+
+	data := &SessionData{}
+	if err := json.Unmarshal(b, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (f *FileSessionStore) Save(ctx context.Context, key string, data SessionData) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if f.passphrase != nil {
+		if b, err = f.encrypt(b); err != nil {
+			return err
+		}
+	}
+
+	return writeFileAtomic(f.path(key), b)
+}
+
+// writeFileAtomic replaces path with b through a temporary file, so readers never see a partial
+// file. Files are only readable by the owner.
+func writeFileAtomic(path string, b []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, sessionDirMode); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(sessionFileMode); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (f *FileSessionStore) Delete(ctx context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	err := os.Remove(f.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (f *FileSessionStore) aead(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(f.passphrase, salt, scryptN, scryptR, scryptP, aesKeySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt returns salt | nonce | ciphertext
+func (f *FileSessionStore) encrypt(plaintext []byte) ([]byte, error) {
+	salt := make([]byte, scryptSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	aead, err := f.aead(salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	out := append(salt, nonce...)
+	return aead.Seal(out, nonce, plaintext, nil), nil
+}
+
+func (f *FileSessionStore) decrypt(b []byte) ([]byte, error) {
+	if len(b) < scryptSaltSize {
+		return nil, errors.New("invalid encrypted session")
+	}
+	aead, err := f.aead(b[:scryptSaltSize])
+	if err != nil {
+		return nil, err
+	}
+	b = b[scryptSaltSize:]
+	if len(b) < aead.NonceSize() {
+		return nil, errors.New("invalid encrypted session")
+	}
+	plaintext, err := aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt session: %w", err)
+	}
+	return plaintext, nil
+}
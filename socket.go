@@ -6,6 +6,7 @@ import (
 	"io"
 	"net"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -24,16 +25,58 @@ var (
 	}
 )
 
+const defaultSocketWriteQueueSize = 64
+
+type SocketOption struct {
+	// WriteQueueSize bounds the messages waiting for the writer, Write blocks while it is full.
+	WriteQueueSize int
+}
+
+// Socket is a realtime connection. Writes are serialized by a single writer goroutine, so Write
+// is safe for concurrent use.
 type Socket struct {
 	session *Session
 	conn    net.Conn
 	reader  *wsutil.Reader
 
+	writes     chan *socketWrite
+	writerDone chan struct{}
+	closed     chan struct{}
+	closeOnce  *sync.Once
+	writeErr   atomic.Pointer[error]
+
+	secure    bool
+	create    bool
+	opt       SocketOption
+	keepAlive *keepAlive
+
 	watching  bool
 	idCounter *atomic.Int64
 }
 
-func (s *Session) NewSocket(ctx context.Context, secure, create bool) (*Socket, error) {
+// keepAlive is carried over to the sockets redialed after a disconnect.
+type keepAlive struct {
+	interval time.Duration
+	stop     chan struct{}
+	stopOnce *sync.Once
+}
+
+// socketWrite is a queued frame, a write without a frame is a flush marker.
+type socketWrite struct {
+	frame    []byte
+	deadline time.Time
+	done     chan error
+}
+
+func (s *Session) NewSocket(ctx context.Context, secure, create bool, opts ...SocketOption) (*Socket, error) {
+	opt := SocketOption{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.WriteQueueSize <= 0 {
+		opt.WriteQueueSize = defaultSocketWriteQueueSize
+	}
+
 	scheme := "ws"
 	if secure {
 		scheme = "wss"
@@ -49,47 +92,99 @@ func (s *Session) NewSocket(ctx context.Context, secure, create bool) (*Socket,
 	}
 
 	sock := &Socket{
-		session:   s,
-		conn:      conn,
-		reader:    wsutil.NewClientSideReader(conn),
-		idCounter: &atomic.Int64{},
+		session:    s,
+		conn:       conn,
+		reader:     wsutil.NewClientSideReader(conn),
+		writes:     make(chan *socketWrite, opt.WriteQueueSize),
+		writerDone: make(chan struct{}),
+		closed:     make(chan struct{}),
+		closeOnce:  &sync.Once{},
+		secure:     secure,
+		create:     create,
+		opt:        opt,
+		idCounter:  &atomic.Int64{},
 	}
+	go sock.writeLoop()
 
 	return sock, nil
 }
 
+// Close closes the connection right away, queued messages that were not written yet fail with
+// ErrSocketClosed. Call Flush first to deliver them.
 func (s *Socket) Close() error {
-	err := s.conn.Close()
-	s.conn = nil
+	err := ErrSocketClosed
+	s.closeOnce.Do(func() {
+		close(s.closed)
+		err = s.conn.Close()
+		<-s.writerDone
+	})
 	return err
 }
 
+func (s *Socket) isClosed() bool {
+	select {
+	case <-s.closed:
+		return true
+	default:
+		return false
+	}
+}
+
 func (s *Socket) KeepAlive(interval time.Duration) (func(), error) {
-	if s.conn == nil {
-		return nil, fmt.Errorf("socket closed")
+	if s.isClosed() {
+		return nil, ErrSocketClosed
 	}
 
-	ticker := time.NewTicker(interval)
+	ka := &keepAlive{
+		interval: interval,
+		stop:     make(chan struct{}),
+		stopOnce: &sync.Once{},
+	}
+	s.keepAlive = ka
+	go s.ping(ka)
 
-	go func() {
-		for range ticker.C {
-			if s.conn == nil {
-				return
-			}
+	return func() {
+		ka.stopOnce.Do(func() {
+			close(ka.stop)
+		})
+	}, nil
+}
 
-			if err := s.Write(context.Background(), &rtapi.Envelope{
-				Message: &rtapi.Envelope_Ping{
-					Ping: &rtapi.Ping{},
-				},
-			}); err != nil {
-				return
-			}
+func (s *Socket) ping(ka *keepAlive) {
+	ticker := time.NewTicker(ka.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ka.stop:
+			return
+		case <-s.closed:
+			return
+		case <-ticker.C:
 		}
-	}()
 
-	return func() {
-		ticker.Stop()
-	}, nil
+		if err := s.Write(context.Background(), &rtapi.Envelope{
+			Message: &rtapi.Envelope_Ping{
+				Ping: &rtapi.Ping{},
+			},
+		}); err != nil {
+			return
+		}
+	}
+}
+
+// redial opens a new socket with the same parameters and a fresh token, the keep alive of this
+// socket continues on the new one.
+func (s *Socket) redial(ctx context.Context) (*Socket, error) {
+	sock, err := s.session.NewSocket(ctx, s.secure, s.create, s.opt)
+	if err != nil {
+		return nil, err
+	}
+	sock.idCounter = s.idCounter
+	if s.keepAlive != nil {
+		sock.keepAlive = s.keepAlive
+		go sock.ping(s.keepAlive)
+	}
+	return sock, nil
 }
 
 func (s *Socket) Read(ctx context.Context) (*rtapi.Envelope, error) {
@@ -106,8 +201,8 @@ func (s *Socket) Read(ctx context.Context) (*rtapi.Envelope, error) {
 }
 
 func (s *Socket) read(ctx context.Context) (*rtapi.Envelope, error) {
-	if s.conn == nil {
-		return nil, fmt.Errorf("socket closed")
+	if s.isClosed() {
+		return nil, ErrSocketClosed
 	}
 
 	for {
@@ -148,11 +243,9 @@ func (s *Socket) newCID() string {
 	return strconv.FormatInt(s.idCounter.Add(1), 10)
 }
 
+// Write queues the message for the writer goroutine and waits until it is written. It gives up
+// when ctx is done, the message may still be written if it was already queued.
 func (s *Socket) Write(ctx context.Context, message *rtapi.Envelope) error {
-	if s.conn == nil {
-		return fmt.Errorf("socket closed")
-	}
-
 	if message.Cid == "" {
 		message.Cid = s.newCID()
 	}
@@ -160,12 +253,72 @@ func (s *Socket) Write(ctx context.Context, message *rtapi.Envelope) error {
 	if err != nil {
 		return err
 	}
+	return s.enqueue(ctx, buf)
+}
 
+// Flush waits until every message queued before it is written.
+func (s *Socket) Flush(ctx context.Context) error {
+	return s.enqueue(ctx, nil)
+}
+
+func (s *Socket) enqueue(ctx context.Context, frame []byte) error {
+	if s.isClosed() {
+		return ErrSocketClosed
+	}
+	if err := s.writeErr.Load(); err != nil {
+		return *err
+	}
+
+	w := &socketWrite{
+		frame: frame,
+		done:  make(chan error, 1),
+	}
 	if deadline, ok := ctx.Deadline(); ok {
-		if err := s.conn.SetWriteDeadline(deadline); err != nil {
-			return err
-		}
+		w.deadline = deadline
+	}
+	select {
+	case s.writes <- w:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.closed:
+		return ErrSocketClosed
 	}
 
-	return wsutil.WriteClientMessage(s.conn, ws.OpText, buf)
+	select {
+	case err := <-w.done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.closed:
+		return ErrSocketClosed
+	}
+}
+
+// writeLoop is the only goroutine writing to the connection. A failed write breaks the frame
+// stream, so it fails every following write as well.
+func (s *Socket) writeLoop() {
+	defer close(s.writerDone)
+	for {
+		select {
+		case <-s.closed:
+			return
+		case w := <-s.writes:
+			if err := s.writeErr.Load(); err != nil {
+				w.done <- *err
+				continue
+			}
+			if w.frame == nil {
+				w.done <- nil
+				continue
+			}
+			err := s.conn.SetWriteDeadline(w.deadline)
+			if err == nil {
+				err = wsutil.WriteClientMessage(s.conn, ws.OpText, w.frame)
+			}
+			if err != nil {
+				s.writeErr.Store(&err)
+			}
+			w.done <- err
+		}
+	}
 }
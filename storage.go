@@ -0,0 +1,304 @@
+package nakama_client_go
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	// storageVersionAbsent only writes the object if it does not exist yet.
+	storageVersionAbsent = "*"
+)
+
+// DefaultConflictRetryPolicy retries Collection.Update when another writer changed the object
+// between the read and the write.
+var DefaultConflictRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 50 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+type CollectionOption struct {
+	// PermissionRead and PermissionWrite of written objects, the server defaults to owner only.
+	PermissionRead  *int32
+	PermissionWrite *int32
+	// ConflictRetry defaults to DefaultConflictRetryPolicy.
+	ConflictRetry *RetryPolicy
+	// Migrations upgrade values written with older schema versions when they are read, with
+	// WriteBackMigrations the upgraded values are stored again if nobody changed them meanwhile.
+	Migrations          *Migrations
+	WriteBackMigrations bool
+	OnWriteBackError    func(key string, err error)
+	// Encryption encrypts values before they are written and decrypts them when they are read.
+	Encryption  *StorageEncryption
+	CallOptions []CallOption
+}
+
+// Collection reads and writes storage objects of the session user as JSON encoded T.
+type Collection[T any] struct {
+	session *Session
+	name    string
+	opt     CollectionOption
+}
+
+// StorageObject is a decoded storage object, Version is used for conditional writes.
+type StorageObject[T any] struct {
+	Collection      string
+	Key             string
+	UserID          string
+	Version         string
+	Value           T
+	PermissionRead  int32
+	PermissionWrite int32
+	CreateTime      time.Time
+	UpdateTime      time.Time
+}
+
+func NewCollection[T any](session *Session, name string, opts ...CollectionOption) *Collection[T] {
+	opt := CollectionOption{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.ConflictRetry == nil {
+		opt.ConflictRetry = &DefaultConflictRetryPolicy
+	}
+	return &Collection[T]{
+		session: session,
+		name:    name,
+		opt:     opt,
+	}
+}
+
+func (c *Collection[T]) Name() string {
+	return c.name
+}
+
+// Get reads an object of the session user, it returns ErrNotFound if the object does not exist.
+func (c *Collection[T]) Get(ctx context.Context, key string) (*StorageObject[T], error) {
+	return c.Read(ctx, c.session.UserID(), key)
+}
+
+// Read reads an object of any user, subject to its read permission.
+func (c *Collection[T]) Read(ctx context.Context, userID, key string) (*StorageObject[T], error) {
+	res, err := c.session.ReadStorageObjects(ctx, &api.ReadStorageObjectsRequest{
+		ObjectIds: []*api.ReadStorageObjectId{{
+			Collection: c.name,
+			Key:        key,
+			UserId:     userID,
+		}},
+	}, c.opt.CallOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Objects) == 0 {
+		return nil, fmt.Errorf("storage object %s/%s: %w", c.name, key, ErrNotFound)
+	}
+	return c.decodeRead(ctx, res.Objects[0])
+}
+
+// List iterates the objects of userID in the collection, or public objects of all users if
+// userID is empty.
+func (c *Collection[T]) List(ctx context.Context, userID string, pageSize int32, opts ...IterOption) iter.Seq2[*StorageObject[T], error] {
+	req := &api.ListStorageObjectsRequest{
+		UserId:     userID,
+		Collection: c.name,
+	}
+	if pageSize > 0 {
+		req.Limit = wrapperspb.Int32(pageSize)
+	}
+	opt := parseIterOptions(opts...)
+	if opt.CallOptions == nil {
+		opt.CallOptions = c.opt.CallOptions
+	}
+	return func(yield func(*StorageObject[T], error) bool) {
+		for obj, err := range c.session.ListStorageObjectsIter(ctx, req, opt) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			decoded, err := c.decodeRead(ctx, obj)
+			if !yield(decoded, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Put writes the object regardless of its current version.
+func (c *Collection[T]) Put(ctx context.Context, key string, value T) (*StorageObject[T], error) {
+	return c.write(ctx, key, value, "")
+}
+
+// PutIfMatch writes the object only if its current version is version, otherwise it returns an
+// error matching ErrVersionConflict.
+func (c *Collection[T]) PutIfMatch(ctx context.Context, key string, value T, version string) (*StorageObject[T], error) {
+	return c.write(ctx, key, value, version)
+}
+
+// Create writes the object only if it does not exist, otherwise it returns an error matching
+// ErrAlreadyExists.
+func (c *Collection[T]) Create(ctx context.Context, key string, value T) (*StorageObject[T], error) {
+	obj, err := c.write(ctx, key, value, storageVersionAbsent)
+	if errors.Is(err, ErrVersionConflict) {
+		return nil, fmt.Errorf("%w: %w", ErrAlreadyExists, err)
+	}
+	return obj, err
+}
+
+// Update reads the object, applies update to its value (the zero value if it does not exist)
+// and writes it back if nobody else changed it in between. Conflicting writes are retried with
+// backoff, so update may be called more than once.
+func (c *Collection[T]) Update(ctx context.Context, key string, update func(value *T) error) (*StorageObject[T], error) {
+	policy := c.opt.ConflictRetry
+	for attempt := 0; ; attempt++ {
+		var value T
+		version := storageVersionAbsent
+		current, err := c.Get(ctx, key)
+		switch {
+		case errors.Is(err, ErrNotFound):
+		case err != nil:
+			return nil, err
+		default:
+			value = current.Value
+			version = current.Version
+		}
+
+		if err := update(&value); err != nil {
+			return nil, err
+		}
+		obj, err := c.write(ctx, key, value, version)
+		if err == nil || !errors.Is(err, ErrVersionConflict) || attempt >= policy.MaxAttempts-1 {
+			return obj, err
+		}
+		if !sleepContext(ctx, policy.backoff(attempt)) {
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Delete removes the object, if version is not empty only if it is the current version.
+func (c *Collection[T]) Delete(ctx context.Context, key, version string) error {
+	return c.session.DeleteStorageObjects(ctx, &api.DeleteStorageObjectsRequest{
+		ObjectIds: []*api.DeleteStorageObjectId{{
+			Collection: c.name,
+			Key:        key,
+			Version:    version,
+		}},
+	}, c.opt.CallOptions...)
+}
+
+func (c *Collection[T]) write(ctx context.Context, key string, value T, version string) (*StorageObject[T], error) {
+	encoded, err := c.encode(key, value)
+	if err != nil {
+		return nil, err
+	}
+	obj := &api.WriteStorageObject{
+		Collection: c.name,
+		Key:        key,
+		Value:      encoded,
+		Version:    version,
+	}
+	if c.opt.PermissionRead != nil {
+		obj.PermissionRead = wrapperspb.Int32(*c.opt.PermissionRead)
+	}
+	if c.opt.PermissionWrite != nil {
+		obj.PermissionWrite = wrapperspb.Int32(*c.opt.PermissionWrite)
+	}
+	return c.writeObject(ctx, obj, value)
+}
+
+func (c *Collection[T]) writeObject(ctx context.Context, obj *api.WriteStorageObject, value T) (*StorageObject[T], error) {
+	key := obj.Key
+	res, err := c.session.WriteStorageObjects(ctx, &api.WriteStorageObjectsRequest{
+		Objects: []*api.WriteStorageObject{obj},
+	}, c.opt.CallOptions...)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Acks) == 0 {
+		return nil, fmt.Errorf("storage object %s/%s: no write acknowledgement", c.name, key)
+	}
+	ack := res.Acks[0]
+	return &StorageObject[T]{
+		Collection:      ack.Collection,
+		Key:             ack.Key,
+		UserID:          ack.UserId,
+		Version:         ack.Version,
+		Value:           value,
+		PermissionRead:  obj.PermissionRead.GetValue(),
+		PermissionWrite: obj.PermissionWrite.GetValue(),
+		CreateTime:      ack.CreateTime.AsTime(),
+		UpdateTime:      ack.UpdateTime.AsTime(),
+	}, nil
+}
+
+func (c *Collection[T]) encode(key string, value T) (string, error) {
+	b, err := json.Marshal(value)
+	if err == nil && c.opt.Migrations != nil {
+		b, err = c.opt.Migrations.stamp(b)
+	}
+	if err != nil {
+		return "", fmt.Errorf("encode storage object: %w", err)
+	}
+	if c.opt.Encryption == nil {
+		return string(b), nil
+	}
+	encrypted, err := c.opt.Encryption.encrypt(c.name, key, c.session.UserID(), string(b))
+	if err != nil {
+		return "", fmt.Errorf("encode storage object: %w", err)
+	}
+	return encrypted, nil
+}
+
+func (c *Collection[T]) decodeRead(ctx context.Context, obj *api.StorageObject) (*StorageObject[T], error) {
+	decoded, migrated, err := c.decode(obj)
+	if err != nil {
+		return nil, err
+	}
+	if migrated && c.opt.WriteBackMigrations {
+		return c.writeBack(ctx, decoded), nil
+	}
+	return decoded, nil
+}
+
+func (c *Collection[T]) decode(obj *api.StorageObject) (*StorageObject[T], bool, error) {
+	b := []byte(obj.Value)
+	if c.opt.Encryption != nil {
+		decrypted, err := c.opt.Encryption.decrypt(obj.Collection, obj.Key, obj.UserId, obj.Value)
+		if err != nil {
+			return nil, false, fmt.Errorf("decode storage object %s/%s: %w", obj.Collection, obj.Key, err)
+		}
+		b = []byte(decrypted)
+	}
+	migrated := false
+	if c.opt.Migrations != nil {
+		var err error
+		if b, migrated, err = c.opt.Migrations.migrate(b); err != nil {
+			return nil, false, fmt.Errorf("decode storage object %s/%s: %w", obj.Collection, obj.Key, err)
+		}
+	}
+	var value T
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, false, fmt.Errorf("decode storage object %s/%s: %w", obj.Collection, obj.Key, err)
+	}
+	return &StorageObject[T]{
+		Collection:      obj.Collection,
+		Key:             obj.Key,
+		UserID:          obj.UserId,
+		Version:         obj.Version,
+		Value:           value,
+		PermissionRead:  obj.PermissionRead,
+		PermissionWrite: obj.PermissionWrite,
+		CreateTime:      obj.CreateTime.AsTime(),
+		UpdateTime:      obj.UpdateTime.AsTime(),
+	}, migrated, nil
+}
@@ -0,0 +1,487 @@
+package nakama_client_go
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	storageCacheObjectsDir = "objects"
+	storageCacheQueueDir   = "queue"
+	storageCacheFileExt    = ".json"
+
+	// queued writes are cached under a local version until the server assigns one, later writes
+	// based on it are rewritten with the server version once it is flushed
+	localVersionPrefix = "local:"
+
+	maxConflictResolutions = 3
+)
+
+// ConflictResolver decides what to write when a queued write is rejected because the server
+// object changed. server is nil if the object no longer exists. Returning nil drops the write.
+type ConflictResolver func(local *api.WriteStorageObject, server *api.StorageObject) (*api.WriteStorageObject, error)
+
+var (
+	// LastWriterWins overwrites the server object with the queued write.
+	LastWriterWins ConflictResolver = func(local *api.WriteStorageObject, server *api.StorageObject) (*api.WriteStorageObject, error) {
+		write := proto.Clone(local).(*api.WriteStorageObject)
+		write.Version = ""
+		return write, nil
+	}
+	// ServerWins drops the queued write.
+	ServerWins ConflictResolver = func(local *api.WriteStorageObject, server *api.StorageObject) (*api.WriteStorageObject, error) {
+		return nil, nil
+	}
+)
+
+// MergeConflicts writes the result of merging the queued and the server values, server is empty
+// if the object no longer exists.
+func MergeConflicts(merge func(local, server string) (string, error)) ConflictResolver {
+	return func(local *api.WriteStorageObject, server *api.StorageObject) (*api.WriteStorageObject, error) {
+		write := proto.Clone(local).(*api.WriteStorageObject)
+		write.Version = storageVersionAbsent
+		serverValue := ""
+		if server != nil {
+			write.Version = server.Version
+			serverValue = server.Value
+		}
+		value, err := merge(local.Value, serverValue)
+		if err != nil {
+			return nil, err
+		}
+		write.Value = value
+		return write, nil
+	}
+}
+
+type StorageCacheOption struct {
+	// Resolver defaults to LastWriterWins.
+	Resolver ConflictResolver
+	// OnWriteFailed is called when a queued write is dropped because the server rejected it, e.g.
+	// as invalid, for missing permissions or for conflicting more often than it could be resolved.
+	// Writes failing for any other reason stay queued and fail the flush.
+	OnWriteFailed func(write *api.WriteStorageObject, err error)
+}
+
+// StorageCache serves storage reads from a disk cache and queues writes while the server can
+// not be reached. Queued writes are flushed in order before the next call that reaches the
+// server, or explicitly with Flush.
+type StorageCache struct {
+	mu      *sync.Mutex
+	session *Session
+	dir     string
+	opt     StorageCacheOption
+	nextSeq uint64
+	// flushed maps the local versions of flushed writes to their server versions
+	flushed map[string]string
+}
+
+func NewStorageCache(session *Session, dir string, opts ...StorageCacheOption) (*StorageCache, error) {
+	opt := StorageCacheOption{}
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Resolver == nil {
+		opt.Resolver = LastWriterWins
+	}
+	c := &StorageCache{
+		mu:      &sync.Mutex{},
+		session: session,
+		dir:     dir,
+		opt:     opt,
+		flushed: map[string]string{},
+	}
+	seqs, err := c.queued()
+	if err != nil {
+		return nil, err
+	}
+	if len(seqs) > 0 {
+		c.nextSeq = seqs[len(seqs)-1] + 1
+	}
+	return c, nil
+}
+
+// Pending is the number of queued writes.
+func (c *StorageCache) Pending() (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	seqs, err := c.queued()
+	return len(seqs), err
+}
+
+// ReadStorageObjects reads from the server and caches the result, falling back to the cache
+// while offline.
+func (c *StorageCache) ReadStorageObjects(ctx context.Context, req *api.ReadStorageObjectsRequest, opts ...CallOption) (*api.StorageObjects, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	err := c.flush(ctx, opts...)
+	var res *api.StorageObjects
+	if err == nil {
+		res, err = c.session.ReadStorageObjects(ctx, req, opts...)
+	}
+	if err != nil {
+		if !isOfflineError(ctx, err) {
+			return nil, err
+		}
+		return c.readCached(req)
+	}
+
+	found := map[string]bool{}
+	for _, obj := range res.Objects {
+		found[c.objectPath(obj.Collection, obj.UserId, obj.Key)] = true
+		if err := c.cache(obj); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range req.ObjectIds {
+		if path := c.objectPath(id.Collection, id.UserId, id.Key); !found[path] {
+			if err := removeFile(path); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// WriteStorageObjects writes to the server, or queues the writes while offline. Queued writes
+// are acknowledged with a local version, which can be used as the version of later writes to
+// the same cache, also after the queue has been flushed.
+func (c *StorageCache) WriteStorageObjects(ctx context.Context, req *api.WriteStorageObjectsRequest, opts ...CallOption) (*api.StorageObjectAcks, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	err := c.flush(ctx, opts...)
+	req = c.serverVersions(req)
+	var res *api.StorageObjectAcks
+	if err == nil {
+		res, err = c.session.WriteStorageObjects(ctx, req, opts...)
+	}
+	if err != nil {
+		if !isOfflineError(ctx, err) {
+			return nil, err
+		}
+		return c.enqueue(req.Objects)
+	}
+
+	for i, ack := range res.Acks {
+		if i < len(req.Objects) {
+			if err := c.cache(writtenObject(req.Objects[i], ack.UserId, ack.Version)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// Flush sends the queued writes in order, it stops at the first write that fails and keeps it
+// queued, unless the server rejected it, see OnWriteFailed.
+func (c *StorageCache) Flush(ctx context.Context, opts ...CallOption) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.flush(ctx, opts...)
+}
+
+func (c *StorageCache) flush(ctx context.Context, opts ...CallOption) error {
+	seqs, err := c.queued()
+	if err != nil {
+		return err
+	}
+	for i, seq := range seqs {
+		write, err := c.loadQueued(seq)
+		if err != nil {
+			return err
+		}
+		version, err := c.flushWrite(ctx, write, opts...)
+		if err != nil {
+			return err
+		}
+		if version != "" {
+			c.flushed[localVersionPrefix+strconv.FormatUint(seq, 10)] = version
+			if err := c.rebase(seqs[i+1:], localVersionPrefix+strconv.FormatUint(seq, 10), version); err != nil {
+				return err
+			}
+		}
+		if err := removeFile(c.queuePath(seq)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serverVersions rewrites the local versions of flushed writes in req to their server versions.
+func (c *StorageCache) serverVersions(req *api.WriteStorageObjectsRequest) *api.WriteStorageObjectsRequest {
+	var rewritten *api.WriteStorageObjectsRequest
+	for i, write := range req.Objects {
+		version, ok := c.flushed[write.Version]
+		if !ok {
+			continue
+		}
+		if rewritten == nil {
+			rewritten = proto.Clone(req).(*api.WriteStorageObjectsRequest)
+		}
+		rewritten.Objects[i].Version = version
+	}
+	if rewritten == nil {
+		return req
+	}
+	return rewritten
+}
+
+// flushWrite returns the version of the written object, or an empty version if it was dropped.
+func (c *StorageCache) flushWrite(ctx context.Context, local *api.WriteStorageObject, opts ...CallOption) (string, error) {
+	write := local
+	for attempt := 0; ; attempt++ {
+		res, err := c.session.WriteStorageObjects(ctx, &api.WriteStorageObjectsRequest{
+			Objects: []*api.WriteStorageObject{write},
+		}, opts...)
+		if err == nil && len(res.Acks) > 0 {
+			ack := res.Acks[0]
+			return ack.Version, c.cache(writtenObject(write, ack.UserId, ack.Version))
+		}
+		if err == nil {
+			return "", fmt.Errorf("storage object %s/%s: no write acknowledgement", write.Collection, write.Key)
+		}
+		conflict := errors.Is(err, ErrVersionConflict)
+		if ctx.Err() != nil || (!conflict && !isRejectedWrite(ctx, err)) {
+			return "", err
+		}
+		// nakama rejects writes lacking permission like conflicting ones, so an unconditional
+		// write, or one still conflicting after resolution, is given up on
+		if !conflict || write.Version == "" || attempt >= maxConflictResolutions {
+			return "", c.drop(ctx, local, write, err, opts...)
+		}
+
+		server, err := c.readServer(ctx, local, opts...)
+		if err != nil {
+			return "", err
+		}
+		if write, err = c.opt.Resolver(local, server); err != nil {
+			return "", err
+		}
+		if write == nil {
+			return "", c.reset(local, server)
+		}
+	}
+}
+
+// drop gives up on a queued write and reports it to OnWriteFailed.
+func (c *StorageCache) drop(ctx context.Context, local, write *api.WriteStorageObject, err error, opts ...CallOption) error {
+	server, readErr := c.readServer(ctx, local, opts...)
+	if readErr != nil {
+		return readErr
+	}
+	if c.opt.OnWriteFailed != nil {
+		c.opt.OnWriteFailed(write, err)
+	}
+	return c.reset(local, server)
+}
+
+// reset replaces the cached object of a dropped write with the server object.
+func (c *StorageCache) reset(local *api.WriteStorageObject, server *api.StorageObject) error {
+	if server != nil {
+		return c.cache(server)
+	}
+	return removeFile(c.objectPath(local.Collection, c.session.UserID(), local.Key))
+}
+
+func (c *StorageCache) readServer(ctx context.Context, write *api.WriteStorageObject, opts ...CallOption) (*api.StorageObject, error) {
+	res, err := c.session.ReadStorageObjects(ctx, &api.ReadStorageObjectsRequest{
+		ObjectIds: []*api.ReadStorageObjectId{{
+			Collection: write.Collection,
+			Key:        write.Key,
+			UserId:     c.session.UserID(),
+		}},
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Objects) == 0 {
+		return nil, nil
+	}
+	return res.Objects[0], nil
+}
+
+// rebase points queued writes based on a flushed local version to its server version.
+func (c *StorageCache) rebase(seqs []uint64, localVersion, version string) error {
+	for _, seq := range seqs {
+		write, err := c.loadQueued(seq)
+		if err != nil {
+			return err
+		}
+		if write.Version != localVersion {
+			continue
+		}
+		write.Version = version
+		if err := c.storeQueued(seq, write); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *StorageCache) enqueue(writes []*api.WriteStorageObject) (*api.StorageObjectAcks, error) {
+	res := &api.StorageObjectAcks{}
+	userID := c.session.UserID()
+	for _, write := range writes {
+		seq := c.nextSeq
+		if err := c.storeQueued(seq, write); err != nil {
+			return nil, err
+		}
+		c.nextSeq++
+
+		version := localVersionPrefix + strconv.FormatUint(seq, 10)
+		if err := c.cache(writtenObject(write, userID, version)); err != nil {
+			return nil, err
+		}
+		res.Acks = append(res.Acks, &api.StorageObjectAck{
+			Collection: write.Collection,
+			Key:        write.Key,
+			Version:    version,
+			UserId:     userID,
+		})
+	}
+	return res, nil
+}
+
+func (c *StorageCache) readCached(req *api.ReadStorageObjectsRequest) (*api.StorageObjects, error) {
+	res := &api.StorageObjects{}
+	for _, id := range req.ObjectIds {
+		b, err := os.ReadFile(c.objectPath(id.Collection, id.UserId, id.Key))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		obj := &api.StorageObject{}
+		if err := protojson.Unmarshal(b, obj); err != nil {
+			return nil, err
+		}
+		res.Objects = append(res.Objects, obj)
+	}
+	return res, nil
+}
+
+func (c *StorageCache) cache(obj *api.StorageObject) error {
+	b, err := protojson.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(c.objectPath(obj.Collection, obj.UserId, obj.Key), b)
+}
+
+func (c *StorageCache) objectPath(collection, userID, key string) string {
+	return filepath.Join(c.dir, storageCacheObjectsDir, url.PathEscape(collection), url.PathEscape(userID), url.PathEscape(key)+storageCacheFileExt)
+}
+
+func (c *StorageCache) queuePath(seq uint64) string {
+	return filepath.Join(c.dir, storageCacheQueueDir, fmt.Sprintf("%020d%s", seq, storageCacheFileExt))
+}
+
+// queued returns the sequence numbers of the queued writes in order.
+func (c *StorageCache) queued() ([]uint64, error) {
+	entries, err := os.ReadDir(filepath.Join(c.dir, storageCacheQueueDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var seqs []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, storageCacheFileExt) || strings.HasPrefix(name, ".") {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, storageCacheFileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	return seqs, nil
+}
+
+func (c *StorageCache) loadQueued(seq uint64) (*api.WriteStorageObject, error) {
+	b, err := os.ReadFile(c.queuePath(seq))
+	if err != nil {
+		return nil, err
+	}
+	write := &api.WriteStorageObject{}
+	if err := protojson.Unmarshal(b, write); err != nil {
+		return nil, fmt.Errorf("queued write %d: %w", seq, err)
+	}
+	return write, nil
+}
+
+func (c *StorageCache) storeQueued(seq uint64, write *api.WriteStorageObject) error {
+	b, err := protojson.Marshal(write)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(c.queuePath(seq), b)
+}
+
+func writtenObject(write *api.WriteStorageObject, userID, version string) *api.StorageObject {
+	return &api.StorageObject{
+		Collection:      write.Collection,
+		Key:             write.Key,
+		UserId:          userID,
+		Value:           write.Value,
+		Version:         version,
+		PermissionRead:  write.PermissionRead.GetValue(),
+		PermissionWrite: write.PermissionWrite.GetValue(),
+	}
+}
+
+// isOfflineError tells apart calls that did not reach the server from rejected ones, calls
+// cancelled by the caller are neither.
+func isOfflineError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if _, ok := status.FromError(err); !ok {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// isRejectedWrite tells whether the server rejected a write for good, retrying it can not succeed.
+func isRejectedWrite(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ErrVersionConflict) {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound:
+		return true
+	}
+	return false
+}
+
+func removeFile(path string) error {
+	err := os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,312 @@
+package nakama_client_go
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"golang.org/x/crypto/hkdf"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	encryptedValueVersion = 1
+	storageKeyInfo        = "nakama-client-go storage key"
+)
+
+var ErrDecryptionFailed = errors.New("decryption failed")
+
+// encryptedValue is the JSON object stored in place of an encrypted value. The value is sealed
+// with a random data key, which is sealed with the key encryption key of the owner.
+type encryptedValue struct {
+	Version int    `json:"_enc"`
+	KeyID   string `json:"kid"`
+	DataKey []byte `json:"dek"`
+	Data    []byte `json:"data"`
+}
+
+// StorageEncryption encrypts storage values with AES-GCM. Every user gets a key encryption key
+// derived from a secret with HKDF, so rotating the secret only re-seals the data keys.
+type StorageEncryption struct {
+	current        string
+	secrets        map[string][]byte
+	allowPlaintext bool
+}
+
+// NewStorageEncryption encrypts new values with secret, identified by keyID in stored values.
+func NewStorageEncryption(keyID string, secret []byte) *StorageEncryption {
+	return &StorageEncryption{
+		current: keyID,
+		secrets: map[string][]byte{keyID: secret},
+	}
+}
+
+// WithKey adds a previous secret, used to decrypt values until they are rotated.
+func (e *StorageEncryption) WithKey(keyID string, secret []byte) *StorageEncryption {
+	e.secrets[keyID] = secret
+	return e
+}
+
+// AllowPlaintext accepts values that are not encrypted, so a collection can be encrypted
+// gradually. Otherwise they fail with ErrDecryptionFailed, as anyone with access to the database
+// could have written them.
+func (e *StorageEncryption) AllowPlaintext() *StorageEncryption {
+	e.allowPlaintext = true
+	return e
+}
+
+func (e *StorageEncryption) userKey(keyID, userID string) (cipher.AEAD, error) {
+	secret, ok := e.secrets[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key %q", ErrDecryptionFailed, keyID)
+	}
+	key := make([]byte, aesKeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, []byte(userID), []byte(storageKeyInfo)), key); err != nil {
+		return nil, err
+	}
+	return newGCM(key)
+}
+
+// encrypt binds the value to the object it is stored in, so it can not be moved to another one.
+func (e *StorageEncryption) encrypt(collection, key, userID, value string) (string, error) {
+	dataKey := make([]byte, aesKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", err
+	}
+	data, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	sealedDataKey, err := e.sealDataKey(e.current, userID, dataKey)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(data, []byte(value), objectAAD(collection, key, userID))
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(encryptedValue{
+		Version: encryptedValueVersion,
+		KeyID:   e.current,
+		DataKey: sealedDataKey,
+		Data:    sealed,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (e *StorageEncryption) decrypt(collection, key, userID, value string) (string, error) {
+	enc, ok := parseEncryptedValue(value)
+	if !ok {
+		if e.allowPlaintext {
+			return value, nil
+		}
+		return "", fmt.Errorf("%w: value is not encrypted", ErrDecryptionFailed)
+	}
+	dataKey, err := e.openDataKey(enc.KeyID, userID, enc.DataKey)
+	if err != nil {
+		return "", err
+	}
+	data, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(data, enc.Data, objectAAD(collection, key, userID))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// rotate re-seals the data key of a value encrypted with a previous key and encrypts allowed
+// plaintext values, it reports false if the value already uses the current key.
+func (e *StorageEncryption) rotate(collection, key, userID, value string) (string, bool, error) {
+	enc, ok := parseEncryptedValue(value)
+	if !ok {
+		if !e.allowPlaintext {
+			return "", false, fmt.Errorf("%w: value is not encrypted", ErrDecryptionFailed)
+		}
+		encrypted, err := e.encrypt(collection, key, userID, value)
+		return encrypted, err == nil, err
+	}
+	if enc.KeyID == e.current {
+		return value, false, nil
+	}
+	dataKey, err := e.openDataKey(enc.KeyID, userID, enc.DataKey)
+	if err != nil {
+		return "", false, err
+	}
+	if enc.DataKey, err = e.sealDataKey(e.current, userID, dataKey); err != nil {
+		return "", false, err
+	}
+	enc.KeyID = e.current
+	b, err := json.Marshal(enc)
+	if err != nil {
+		return "", false, err
+	}
+	return string(b), true, nil
+}
+
+func (e *StorageEncryption) sealDataKey(keyID, userID string, dataKey []byte) ([]byte, error) {
+	userKey, err := e.userKey(keyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return seal(userKey, dataKey, []byte(keyID))
+}
+
+func (e *StorageEncryption) openDataKey(keyID, userID string, sealed []byte) ([]byte, error) {
+	userKey, err := e.userKey(keyID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return open(userKey, sealed, []byte(keyID))
+}
+
+func rewrittenObject(obj *api.StorageObject, value string) *api.WriteStorageObject {
+	return &api.WriteStorageObject{
+		Collection:      obj.Collection,
+		Key:             obj.Key,
+		Value:           value,
+		Version:         obj.Version,
+		PermissionRead:  wrapperspb.Int32(obj.PermissionRead),
+		PermissionWrite: wrapperspb.Int32(obj.PermissionWrite),
+	}
+}
+
+func parseEncryptedValue(value string) (*encryptedValue, bool) {
+	enc := &encryptedValue{}
+	if err := json.Unmarshal([]byte(value), enc); err != nil || enc.Version != encryptedValueVersion {
+		return nil, false
+	}
+	return enc, true
+}
+
+func objectAAD(collection, key, userID string) []byte {
+	b, _ := json.Marshal([]string{collection, key, userID})
+	return b
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal returns nonce | ciphertext
+func seal(aead cipher.AEAD, plaintext, aad []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+func open(aead cipher.AEAD, sealed, aad []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrDecryptionFailed
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], aad)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+	}
+	return plaintext, nil
+}
+
+// EncryptedStorage encrypts values written through it and decrypts values read through it.
+type EncryptedStorage struct {
+	session    *Session
+	encryption *StorageEncryption
+}
+
+func (s *Session) EncryptedStorage(encryption *StorageEncryption) *EncryptedStorage {
+	return &EncryptedStorage{
+		session:    s,
+		encryption: encryption,
+	}
+}
+
+func (es *EncryptedStorage) WriteStorageObjects(ctx context.Context, req *api.WriteStorageObjectsRequest, opts ...CallOption) (*api.StorageObjectAcks, error) {
+	req = proto.Clone(req).(*api.WriteStorageObjectsRequest)
+	userID := es.session.UserID()
+	for _, obj := range req.Objects {
+		value, err := es.encryption.encrypt(obj.Collection, obj.Key, userID, obj.Value)
+		if err != nil {
+			return nil, err
+		}
+		obj.Value = value
+	}
+	return es.session.WriteStorageObjects(ctx, req, opts...)
+}
+
+func (es *EncryptedStorage) ReadStorageObjects(ctx context.Context, req *api.ReadStorageObjectsRequest, opts ...CallOption) (*api.StorageObjects, error) {
+	res, err := es.session.ReadStorageObjects(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return res, es.decryptObjects(res.Objects)
+}
+
+func (es *EncryptedStorage) ListStorageObjects(ctx context.Context, req *api.ListStorageObjectsRequest, opts ...CallOption) (*api.StorageObjectList, error) {
+	res, err := es.session.ListStorageObjects(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return res, es.decryptObjects(res.Objects)
+}
+
+func (es *EncryptedStorage) decryptObjects(objects []*api.StorageObject) error {
+	for _, obj := range objects {
+		value, err := es.encryption.decrypt(obj.Collection, obj.Key, obj.UserId, obj.Value)
+		if err != nil {
+			return fmt.Errorf("storage object %s/%s: %w", obj.Collection, obj.Key, err)
+		}
+		obj.Value = value
+	}
+	return nil
+}
+
+// Rotate re-seals the values of the session user in collection that use a previous key, and
+// encrypts plaintext values if they are allowed. Objects written concurrently are skipped, the
+// writer already used its own key.
+func (es *EncryptedStorage) Rotate(ctx context.Context, collection string, opts ...CallOption) (int, error) {
+	userID := es.session.UserID()
+	rotated := 0
+	req := &api.ListStorageObjectsRequest{UserId: userID, Collection: collection}
+	for obj, err := range es.session.ListStorageObjectsIter(ctx, req, IterOption{CallOptions: opts}) {
+		if err != nil {
+			return rotated, err
+		}
+		value, changed, err := es.encryption.rotate(obj.Collection, obj.Key, userID, obj.Value)
+		if err != nil {
+			return rotated, fmt.Errorf("storage object %s/%s: %w", obj.Collection, obj.Key, err)
+		}
+		if !changed {
+			continue
+		}
+		_, err = es.session.WriteStorageObjects(ctx, &api.WriteStorageObjectsRequest{
+			Objects: []*api.WriteStorageObject{rewrittenObject(obj, value)},
+		}, opts...)
+		if errors.Is(err, ErrVersionConflict) {
+			continue
+		}
+		if err != nil {
+			return rotated, err
+		}
+		rotated++
+	}
+	return rotated, nil
+}
@@ -0,0 +1,121 @@
+package nakama_client_go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/heroiclabs/nakama-common/api"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// storageSchemaField holds the schema version in stored values, values without it are version 1.
+const storageSchemaField = "_schema"
+
+// Migrations upgrade stored values to the current schema version when they are read.
+type Migrations struct {
+	current  int
+	upgrades map[int]func(value map[string]any) error
+}
+
+// NewMigrations starts a registry for values at schema version current, which is written along
+// with every value.
+func NewMigrations(current int) *Migrations {
+	return &Migrations{
+		current:  current,
+		upgrades: map[int]func(value map[string]any) error{},
+	}
+}
+
+// Register upgrades values from schema version from to from+1 in place. Numbers are json.Number.
+func (m *Migrations) Register(from int, upgrade func(value map[string]any) error) *Migrations {
+	m.upgrades[from] = upgrade
+	return m
+}
+
+func (m *Migrations) Current() int {
+	return m.current
+}
+
+// stamp adds the current schema version to an encoded JSON object.
+func (m *Migrations) stamp(b []byte) ([]byte, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, fmt.Errorf("storage values must be JSON objects: %w", err)
+	}
+	fields[storageSchemaField] = json.RawMessage(fmt.Sprint(m.current))
+	return json.Marshal(fields)
+}
+
+// migrate upgrades an encoded JSON object to the current schema version, the returned value has
+// no schema field.
+func (m *Migrations) migrate(b []byte) ([]byte, bool, error) {
+	fields := map[string]any{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, false, err
+	}
+
+	version := 1
+	if v, ok := fields[storageSchemaField]; ok {
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, false, fmt.Errorf("invalid schema version %v", v)
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid schema version %v", v)
+		}
+		version = int(i)
+		delete(fields, storageSchemaField)
+	}
+	if version > m.current {
+		return nil, false, fmt.Errorf("schema version %d is newer than %d", version, m.current)
+	}
+
+	migrated := version < m.current
+	for ; version < m.current; version++ {
+		upgrade, ok := m.upgrades[version]
+		if !ok {
+			return nil, false, fmt.Errorf("no migration from schema version %d", version)
+		}
+		if err := upgrade(fields); err != nil {
+			return nil, false, fmt.Errorf("migrate from schema version %d: %w", version, err)
+		}
+	}
+
+	b, err := json.Marshal(fields)
+	return b, migrated, err
+}
+
+// writeBack stores a migrated object of the session user, unless it changed since it was read.
+// It is best effort, the migrated value is returned either way.
+func (c *Collection[T]) writeBack(ctx context.Context, obj *StorageObject[T]) *StorageObject[T] {
+	if obj.UserID != c.session.UserID() {
+		return obj
+	}
+	encoded, err := c.encode(obj.Key, obj.Value)
+	var written *StorageObject[T]
+	if err == nil {
+		// the permissions of the object are kept, the collection ones only apply to new writes
+		written, err = c.writeObject(ctx, &api.WriteStorageObject{
+			Collection:      c.name,
+			Key:             obj.Key,
+			Value:           encoded,
+			Version:         obj.Version,
+			PermissionRead:  wrapperspb.Int32(obj.PermissionRead),
+			PermissionWrite: wrapperspb.Int32(obj.PermissionWrite),
+		}, obj.Value)
+	}
+	if err != nil {
+		if !errors.Is(err, ErrVersionConflict) && c.opt.OnWriteBackError != nil {
+			c.opt.OnWriteBackError(obj.Key, err)
+		}
+		return obj
+	}
+	written.CreateTime = obj.CreateTime
+	return written
+}
@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nakama_client_go "github.com/joesonw/nakama-client-go"
+	"github.com/joesonw/nakama-client-go/internal/pb"
+)
+
+// generated methods exposed under a different name, "2" suffixed ones are additional HTTP bindings
+var apiMethodAliases = map[string]string{
+	"SessionRefresh":         "Refresh",
+	"SessionLogout":          "Logout",
+	"Event":                  "EmitEvent",
+	"RpcFunc":                "RPC",
+	"RpcFunc2":               "RPC",
+	"ListStorageObjects2":    "ListStorageObjects",
+	"WriteTournamentRecord2": "WriteTournamentRecord",
+}
+
+var _ = Describe("API Coverage Tests", func() {
+	var wrappers []reflect.Type
+
+	BeforeEach(func() {
+		wrappers = []reflect.Type{
+			reflect.TypeOf(&nakama_client_go.Session{}),
+			reflect.TypeOf(&nakama_client_go.Client{}),
+			reflect.TypeOf(&nakama_client_go.ServerClient{}),
+		}
+	})
+
+	It("should wrap every api method on Session, Client or ServerClient", func() {
+		api := reflect.TypeOf((*pb.NakamaClientInterface)(nil)).Elem()
+		var missing []string
+		for i := 0; i < api.NumMethod(); i++ {
+			name := api.Method(i).Name
+			if alias, ok := apiMethodAliases[name]; ok {
+				name = alias
+			}
+			wrapped := false
+			for _, wrapper := range wrappers {
+				if _, ok := wrapper.MethodByName(name); ok {
+					wrapped = true
+				}
+			}
+			if !wrapped {
+				missing = append(missing, api.Method(i).Name)
+			}
+		}
+		Expect(missing).To(BeEmpty())
+	})
+})
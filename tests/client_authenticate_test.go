@@ -2,9 +2,12 @@ package tests
 
 import (
 	"context"
+	"errors"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	nakama_client_go "github.com/joesonw/nakama-client-go"
 )
@@ -48,6 +51,8 @@ var _ = Describe("Authenticate Tests", func() {
 			Create: nakama_client_go.False(),
 		})
 		Expect(err).Should(HaveOccurred())
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+		Expect(errors.Is(err, nakama_client_go.ErrNotFound)).To(BeTrue())
 	})
 
 	It("should authenticate with custom id twice", func() {
@@ -63,6 +68,7 @@ var _ = Describe("Authenticate Tests", func() {
 	It("should fail to authenticate with custom id", func() {
 		_, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), "")
 		Expect(err).Should(HaveOccurred())
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
 	})
 
 	// It("should authenticate with facebook instant game", func() {
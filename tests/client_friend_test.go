@@ -6,6 +6,9 @@ import (
 	"github.com/heroiclabs/nakama-common/api"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	nakama_client_go "github.com/joesonw/nakama-client-go"
 )
 
 var _ = Describe("Friend Tests", func() {
@@ -116,6 +119,34 @@ var _ = Describe("Friend Tests", func() {
 		Expect(res.Friends).To(HaveLen(0))
 	})
 
+	It("should iterate friends across pages", func() {
+		client := newNakamaHTTPClient()
+		sess, err := client.AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		var ids []string
+		for i := 0; i < 3; i++ {
+			friend, err := client.AuthenticateCustom(context.Background(), generateID())
+			Expect(err).ShouldNot(HaveOccurred())
+			ids = append(ids, friend.UserID())
+		}
+		Expect(sess.AddFriends(context.Background(), &api.AddFriendsRequest{Ids: ids})).ShouldNot(HaveOccurred())
+
+		req := &api.ListFriendsRequest{Limit: wrapperspb.Int32(1)}
+		friends, err := nakama_client_go.Collect(sess.ListFriendsIter(context.Background(), req, nakama_client_go.IterOption{Prefetch: true}), 0)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(friends).To(HaveLen(3))
+
+		friends, err = nakama_client_go.Collect(sess.ListFriendsIter(context.Background(), req), 2)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(friends).To(HaveLen(2))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = nakama_client_go.Collect(sess.ListFriendsIter(ctx, req), 0)
+		Expect(err).To(MatchError(context.Canceled))
+	})
+
 	// It("should add friend, accept, then list", func() {
 	//	id1 := generateID()
 	//	id2 := generateID()
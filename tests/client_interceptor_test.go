@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	nakama_client_go "github.com/joesonw/nakama-client-go"
+)
+
+var _ = Describe("Interceptor Tests", func() {
+	for name, newClient := range map[string]func() *nakama_client_go.Client{
+		"http": newNakamaHTTPClient,
+		"grpc": newNakamaGRPCClient,
+	} {
+		newClient := newClient
+
+		It("should intercept calls over "+name, func() {
+			var methods []string
+			var lastErr error
+			client := newClient().WithInterceptors(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				methods = append(methods, method)
+				lastErr = invoker(ctx, method, req, reply, cc, opts...)
+				return lastErr
+			})
+			defer client.Close()
+
+			sess, err := client.AuthenticateCustom(context.Background(), generateID())
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = sess.GetAccount(context.Background())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(methods).To(Equal([]string{
+				"/nakama.api.Nakama/AuthenticateCustom",
+				"/nakama.api.Nakama/GetAccount",
+			}))
+
+			_, err = client.AuthenticateCustom(context.Background(), generateID(), nakama_client_go.AuthenticateOption{
+				Create: nakama_client_go.False(),
+			})
+			Expect(errors.Is(lastErr, nakama_client_go.ErrNotFound)).To(BeTrue())
+			Expect(err).To(Equal(lastErr))
+		})
+
+		It("should apply per-call options over "+name, func() {
+			var sentHeader metadata.MD
+			client := newClient().WithInterceptors(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				sentHeader, _ = metadata.FromOutgoingContext(ctx)
+				return invoker(ctx, method, req, reply, cc, opts...)
+			})
+			defer client.Close()
+
+			sess, err := client.AuthenticateCustom(context.Background(), generateID())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var header metadata.MD
+			_, err = sess.GetAccount(context.Background(),
+				nakama_client_go.CallHeader("x-request-id", "abc"),
+				nakama_client_go.CallResponseHeader(&header),
+				nakama_client_go.CallWithoutRetry(),
+			)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(sentHeader.Get("x-request-id")).To(Equal([]string{"abc"}))
+			Expect(header.Get("content-type")).NotTo(BeEmpty())
+
+			_, err = sess.GetAccount(context.Background(), nakama_client_go.CallTimeout(time.Nanosecond))
+			Expect(errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded).To(BeTrue())
+		})
+	}
+})
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/rtapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	nakama_client_go "github.com/joesonw/nakama-client-go"
+)
+
+var _ = Describe("Error Tests", func() {
+	for name, newClient := range map[string]func() *nakama_client_go.Client{
+		"http": newNakamaHTTPClient,
+		"grpc": newNakamaGRPCClient,
+	} {
+		newClient := newClient
+
+		It("should match sentinel errors over "+name, func() {
+			client := newClient()
+			defer client.Close()
+
+			_, err := client.AuthenticateCustom(context.Background(), generateID(), nakama_client_go.AuthenticateOption{
+				Create: nakama_client_go.False(),
+			})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+			Expect(errors.Is(err, nakama_client_go.ErrNotFound)).To(BeTrue())
+			Expect(errors.Is(err, nakama_client_go.ErrVersionConflict)).To(BeFalse())
+
+			sess, err := client.AuthenticateCustom(context.Background(), generateID())
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = sess.WriteStorageObjects(context.Background(), &api.WriteStorageObjectsRequest{
+				Objects: []*api.WriteStorageObject{{Collection: "errors", Key: "a", Value: `{}`}},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = sess.WriteStorageObjects(context.Background(), &api.WriteStorageObjectsRequest{
+				Objects: []*api.WriteStorageObject{{Collection: "errors", Key: "a", Value: `{}`, Version: "*"}},
+			})
+			Expect(errors.Is(err, nakama_client_go.ErrVersionConflict)).To(BeTrue())
+			Expect(errors.Is(err, nakama_client_go.ErrNotFound)).To(BeFalse())
+		})
+	}
+
+	It("should match sentinel errors from realtime error envelopes", func() {
+		rc := newStartedRealtimeClient()
+		defer rc.Close()
+
+		_, err := rc.JoinMatch(context.Background(), uuid.New().String()+".nakama", "", nil)
+		Expect(nakama_client_go.IsRealtimeError(err)).To(BeTrue())
+		Expect(rtapi.Error_Code(nakama_client_go.AsRealtimeError(err).Code())).To(Equal(rtapi.Error_MATCH_NOT_FOUND))
+		Expect(errors.Is(err, nakama_client_go.ErrNotFound)).To(BeTrue())
+
+		_, err = rc.RPC(context.Background(), "missing_"+generateID(), "", "")
+		Expect(nakama_client_go.IsRealtimeError(err)).To(BeTrue())
+		Expect(errors.Is(err, nakama_client_go.ErrNotFound)).To(BeTrue())
+		Expect(errors.Is(err, nakama_client_go.ErrInvalidArgument)).To(BeFalse())
+	})
+})
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nakama_client_go "github.com/joesonw/nakama-client-go"
+)
+
+var _ = Describe("Lock Tests", func() {
+	It("should exclude other owners and increase the fencing token", func() {
+		sess, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+		first := nakama_client_go.NewLock(sess, "job")
+		second := nakama_client_go.NewLock(sess, "job")
+
+		Expect(first.TryAcquire(context.Background(), time.Minute)).ShouldNot(HaveOccurred())
+		err = second.TryAcquire(context.Background(), time.Minute)
+		Expect(errors.Is(err, nakama_client_go.ErrLockHeld)).To(BeTrue())
+		Expect(first.Renew(context.Background(), time.Minute)).ShouldNot(HaveOccurred())
+		Expect(first.Release(context.Background())).ShouldNot(HaveOccurred())
+
+		Expect(second.TryAcquire(context.Background(), time.Millisecond)).ShouldNot(HaveOccurred())
+		Expect(second.Token()).To(Equal(int64(2)))
+		time.Sleep(10 * time.Millisecond)
+		Expect(first.TryAcquire(context.Background(), time.Minute)).ShouldNot(HaveOccurred())
+		Expect(first.Token()).To(Equal(int64(3)))
+		err = second.Renew(context.Background(), time.Minute)
+		Expect(errors.Is(err, nakama_client_go.ErrLockLost)).To(BeTrue())
+	})
+	It("should elect a single leader", func() {
+		sess, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		leaders := make(chan int64, 2)
+		for i := 0; i < 2; i++ {
+			election := nakama_client_go.NewLeaderElection(sess, "leader", nakama_client_go.LeaderElectionOption{
+				TTL: 3 * time.Second,
+			})
+			go func() {
+				_ = election.Run(ctx, func(ctx context.Context, token int64) {
+					leaders <- token
+					<-ctx.Done()
+				})
+			}()
+		}
+		Eventually(leaders).Should(Receive(Equal(int64(1))))
+		Consistently(leaders, 2*time.Second).ShouldNot(Receive())
+	})
+})
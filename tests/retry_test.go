@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nakama_client_go "github.com/joesonw/nakama-client-go"
+)
+
+var testRetryPolicy = nakama_client_go.RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     20 * time.Millisecond,
+	Multiplier:     2,
+}
+
+var _ = Describe("Retry Tests", func() {
+	var (
+		calls    *atomic.Int64
+		failures *atomic.Int64
+		sess     *nakama_client_go.Session
+	)
+
+	// the server fails the first failures requests with 503 and a Retry-After of an hour
+	BeforeEach(func() {
+		calls, failures = &atomic.Int64{}, &atomic.Int64{}
+		failures.Store(100)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= failures.Load() {
+				w.Header().Set("Retry-After", "3600")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(`{"code":14,"message":"unavailable"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+		}))
+		DeferCleanup(server.Close)
+
+		client := nakama_client_go.NewHTTPClient(strings.TrimPrefix(server.URL, "http://"), nakamaServerKey, false, nil).
+			WithRetryPolicy(testRetryPolicy)
+		var err error
+		sess, err = client.RestoreSession(signSessionToken("key", map[string]any{
+			"uid": generateID(),
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}), "")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should cap Retry-After by the max backoff", func() {
+		failures.Store(2)
+		start := time.Now()
+		_, err := sess.GetAccount(context.Background())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(calls.Load()).To(Equal(int64(3)))
+		Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+	})
+
+	It("should give up after max attempts", func() {
+		_, err := sess.GetAccount(context.Background())
+		Expect(err).Should(HaveOccurred())
+		Expect(calls.Load()).To(Equal(int64(4)))
+	})
+
+	It("should only retry idempotent methods unless listed", func() {
+		req := &api.WriteStorageObjectsRequest{
+			Objects: []*api.WriteStorageObject{{Collection: "c", Key: "k", Value: "{}"}},
+		}
+		_, _ = sess.WriteStorageObjects(context.Background(), req)
+		_, _ = sess.WriteStorageObjects(context.Background(), req, nakama_client_go.CallRetryPolicy(testRetryPolicy))
+		Expect(calls.Load()).To(Equal(int64(2)))
+
+		calls.Store(0)
+		optIn := testRetryPolicy
+		optIn.Methods = []string{"/nakama.api.Nakama/WriteStorageObjects"}
+		_, _ = sess.WriteStorageObjects(context.Background(), req, nakama_client_go.CallRetryPolicy(optIn))
+		Expect(calls.Load()).To(Equal(int64(4)))
+	})
+
+	It("should not retry calls made without retry", func() {
+		_, _ = sess.GetAccount(context.Background(), nakama_client_go.CallWithoutRetry())
+		Expect(calls.Load()).To(Equal(int64(1)))
+	})
+})
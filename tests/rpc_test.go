@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"context"
+	"errors"
+
+	"github.com/heroiclabs/nakama-common/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+
+	nakama_client_go "github.com/joesonw/nakama-client-go"
+)
+
+var _ = Describe("RPC Tests", func() {
+	It("should call typed rpc over session", func() {
+		sess, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = nakama_client_go.CallRPC[map[string]string, map[string]string](context.Background(), sess, "missing_"+generateID(), map[string]string{"k": "v"})
+		Expect(errors.Is(err, nakama_client_go.ErrNotFound)).To(BeTrue())
+	})
+	// the lua module in modules/ takes and returns plain text, which JSON reads as numbers and
+	// strings, it can not decode the base64 payloads of the protobuf and msgpack codecs
+	It("should call rpc defined by the server module", func() {
+		sess, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+		sock, err := sess.NewSocket(context.Background(), false, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		rc, err := sock.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+		rc.Start()
+		defer rc.Close()
+
+		getWorldID := nakama_client_go.NewRPC[string, string]("get_world_id", nakama_client_go.TextCodec)
+		var worldIDs []string
+		for _, caller := range []nakama_client_go.RPCCaller{sess, newNakamaHTTPClient().NewServerClient("defaulthttpkey"), rc} {
+			worldID, err := getWorldID.Call(context.Background(), caller, "")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(worldID).NotTo(BeEmpty())
+			worldIDs = append(worldIDs, worldID)
+		}
+		Expect(worldIDs[1]).To(Equal(worldIDs[0]))
+		Expect(worldIDs[2]).To(Equal(worldIDs[0]))
+
+		name := generateID()
+		registerName := nakama_client_go.NewRPC[string, int]("register_character_name", nakama_client_go.JSONCodec)
+		registered, err := registerName.Call(context.Background(), sess, name)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(registered).To(Equal(1))
+		registered, err = registerName.Call(context.Background(), sess, name)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(registered).To(Equal(0))
+
+		removed, err := nakama_client_go.NewRPC[string, int]("remove_character_name", nakama_client_go.JSONCodec).Call(context.Background(), sess, name)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(removed).To(Equal(1))
+		registered, err = registerName.Call(context.Background(), sess, name)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(registered).To(Equal(1))
+	})
+})
+
+type rpcMessage struct {
+	Name  string `json:"name" msgpack:"name"`
+	Count int    `json:"count" msgpack:"count"`
+}
+
+var _ = Describe("RPC Codec Tests", func() {
+	var registry *nakama_client_go.RPCRegistry
+
+	BeforeEach(func() {
+		registry = nakama_client_go.NewRPCRegistry()
+	})
+
+	for name, codec := range map[string]nakama_client_go.Codec{
+		"json":    nakama_client_go.JSONCodec,
+		"msgpack": nakama_client_go.MsgpackCodec,
+	} {
+		codec := codec
+
+		It("should round trip values with "+name+" codec", func() {
+			b, err := codec.Marshal(rpcMessage{Name: "a", Count: 2})
+			Expect(err).ShouldNot(HaveOccurred())
+			var out rpcMessage
+			Expect(codec.Unmarshal(b, &out)).ShouldNot(HaveOccurred())
+			Expect(out).To(Equal(rpcMessage{Name: "a", Count: 2}))
+		})
+	}
+
+	It("should round trip messages with protobuf codec", func() {
+		b, err := nakama_client_go.ProtobufCodec.Marshal(&api.Rpc{Id: "id", Payload: "payload"})
+		Expect(err).ShouldNot(HaveOccurred())
+		var out *api.Rpc
+		Expect(nakama_client_go.ProtobufCodec.Unmarshal(b, &out)).ShouldNot(HaveOccurred())
+		Expect(proto.Equal(out, &api.Rpc{Id: "id", Payload: "payload"})).To(BeTrue())
+	})
+
+	It("should pass text through with text codec", func() {
+		b, err := nakama_client_go.TextCodec.Marshal("world")
+		Expect(err).ShouldNot(HaveOccurred())
+		var out string
+		Expect(nakama_client_go.TextCodec.Unmarshal(b, &out)).ShouldNot(HaveOccurred())
+		Expect(out).To(Equal("world"))
+		_, err = nakama_client_go.TextCodec.Marshal(1)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should reject registering an rpc with different types", func() {
+		_, err := nakama_client_go.RegisterRPC[rpcMessage, rpcMessage](registry, "echo", nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = nakama_client_go.RegisterRPC[rpcMessage, rpcMessage](registry, "echo", nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = nakama_client_go.RegisterRPC[rpcMessage, string](registry, "echo", nil)
+		Expect(errors.Is(err, nakama_client_go.ErrRPCTypeMismatch)).To(BeTrue())
+	})
+})
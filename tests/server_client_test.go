@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nakama_client_go "github.com/joesonw/nakama-client-go"
+)
+
+var _ = Describe("Server Client Tests", func() {
+	for name, newClient := range map[string]func() *nakama_client_go.Client{
+		"http": newNakamaHTTPClient,
+		"grpc": newNakamaGRPCClient,
+	} {
+		newClient := newClient
+
+		It("should call rpc over "+name, func() {
+			client := newClient()
+			defer client.Close()
+
+			worldID, err := client.NewServerClient("defaulthttpkey").RPC(context.Background(), nakama_client_go.ServerRPCRequest{ID: "get_world_id"})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(worldID).NotTo(BeEmpty())
+
+			getWorldID, err := client.NewServerClient("defaulthttpkey").RPC(context.Background(), nakama_client_go.ServerRPCRequest{
+				ID:     "get_world_id",
+				Method: http.MethodGet,
+				UserID: generateID(),
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(getWorldID).To(Equal(worldID))
+		})
+
+		It("should map rpc errors over "+name, func() {
+			client := newClient()
+			defer client.Close()
+
+			for _, req := range []nakama_client_go.ServerRPCRequest{
+				{ID: "missing_" + generateID(), Payload: []byte(`{}`)},
+				{ID: "missing_" + generateID(), Method: http.MethodGet, UserID: generateID(), Query: url.Values{"k": {"v"}}},
+			} {
+				_, err := client.NewServerClient("defaulthttpkey").RPC(context.Background(), req)
+				Expect(errors.Is(err, nakama_client_go.ErrNotFound)).To(BeTrue())
+			}
+
+			_, err := client.NewServerClient("wrongkey").RPC(context.Background(), nakama_client_go.ServerRPCRequest{ID: "missing"})
+			Expect(errors.Is(err, nakama_client_go.ErrUnauthenticated)).To(BeTrue())
+
+			_, err = client.NewServerClient("defaulthttpkey").RPC(context.Background(), nakama_client_go.ServerRPCRequest{
+				ID:      "missing",
+				Method:  http.MethodGet,
+				Payload: []byte(`{}`),
+			})
+			Expect(errors.Is(err, nakama_client_go.ErrInvalidArgument)).To(BeTrue())
+		})
+	}
+
+	It("should call unwrapped rpc over http", func() {
+		server := newNakamaHTTPClient().NewServerClient("defaulthttpkey")
+
+		wrapped, err := server.RPC(context.Background(), nakama_client_go.ServerRPCRequest{ID: "get_world_id"})
+		Expect(err).ShouldNot(HaveOccurred())
+		unwrapped, err := server.RPC(context.Background(), nakama_client_go.ServerRPCRequest{ID: "get_world_id", Unwrap: true})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(unwrapped).To(Equal(wrapped))
+
+		registered, err := server.RPC(context.Background(), nakama_client_go.ServerRPCRequest{
+			ID:      "register_character_name",
+			Payload: []byte(generateID()),
+			Unwrap:  true,
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(registered)).To(Equal("1"))
+
+		_, err = server.RPC(context.Background(), nakama_client_go.ServerRPCRequest{ID: "missing_" + generateID(), Payload: []byte(`{}`), Unwrap: true})
+		Expect(errors.Is(err, nakama_client_go.ErrNotFound)).To(BeTrue())
+	})
+
+	It("should reject unwrapped rpc over grpc", func() {
+		client := newNakamaGRPCClient()
+		defer client.Close()
+
+		_, err := client.NewServerClient("defaulthttpkey").RPC(context.Background(), nakama_client_go.ServerRPCRequest{ID: "get_world_id", Unwrap: true})
+		Expect(errors.Is(err, nakama_client_go.ErrInvalidArgument)).To(BeTrue())
+	})
+})
@@ -0,0 +1,77 @@
+package tests
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nakama_client_go "github.com/joesonw/nakama-client-go"
+)
+
+var _ = Describe("Session Store Tests", func() {
+	for name, newStore := range map[string]func() nakama_client_go.SessionStore{
+		"memory": func() nakama_client_go.SessionStore {
+			return nakama_client_go.NewMemorySessionStore()
+		},
+		"file": func() nakama_client_go.SessionStore {
+			return nakama_client_go.NewFileSessionStore(GinkgoT().TempDir())
+		},
+		"encrypted file": func() nakama_client_go.SessionStore {
+			return nakama_client_go.NewEncryptedFileSessionStore(GinkgoT().TempDir(), "passphrase")
+		},
+	} {
+		newStore := newStore
+
+		It("should save, load and delete sessions with "+name+" store", func() {
+			store := newStore()
+			client := newNakamaHTTPClient().WithSessionStore(store, "profile")
+
+			sess, err := client.AuthenticateCustom(context.Background(), generateID())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			loaded, err := client.LoadSession(context.Background(), "")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(loaded.Token()).To(Equal(sess.Token()))
+
+			Expect(loaded.Refresh(context.Background())).ShouldNot(HaveOccurred())
+			data, err := store.Load(context.Background(), "profile")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(data.Token).To(Equal(loaded.Token()))
+
+			Expect(loaded.Logout(context.Background())).ShouldNot(HaveOccurred())
+			_, err = store.Load(context.Background(), "profile")
+			Expect(errors.Is(err, nakama_client_go.ErrNotFound)).To(BeTrue())
+		})
+	}
+	It("should keep sessions usable when the store fails", func() {
+		var saveErrs []error
+		client := newNakamaHTTPClient().
+			WithSessionStore(failingSessionStore{}, "profile").
+			OnSessionSaveFailed(func(sess *nakama_client_go.Session, err error) {
+				saveErrs = append(saveErrs, err)
+			})
+
+		sess, err := client.AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(sess.Refresh(context.Background())).ShouldNot(HaveOccurred())
+		_, err = sess.GetAccount(context.Background())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(saveErrs).To(HaveLen(2))
+	})
+})
+
+type failingSessionStore struct{}
+
+func (failingSessionStore) Load(context.Context, string) (*nakama_client_go.SessionData, error) {
+	return nil, errors.New("store unavailable")
+}
+
+func (failingSessionStore) Save(context.Context, string, nakama_client_go.SessionData) error {
+	return errors.New("store unavailable")
+}
+
+func (failingSessionStore) Delete(context.Context, string) error {
+	return errors.New("store unavailable")
+}
@@ -0,0 +1,196 @@
+package tests
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+
+	nakama_client_go "github.com/joesonw/nakama-client-go"
+)
+
+var _ = Describe("Session Tests", func() {
+	It("should notify when refresh fails after the token is revoked", func() {
+		sess, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		var refreshErr error
+		sess.OnRefreshFailed(func(err error) {
+			refreshErr = err
+		})
+		Expect(sess.Logout(context.Background())).ShouldNot(HaveOccurred())
+
+		_, err = sess.GetAccount(context.Background())
+		Expect(err).Should(HaveOccurred())
+		Expect(errors.Is(err, nakama_client_go.ErrUnauthenticated)).To(BeTrue())
+		Expect(refreshErr).Should(HaveOccurred())
+	})
+	It("should refresh and replay calls rejected for a revoked token", func() {
+		client := newNakamaHTTPClient()
+		sess, err := client.AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+		token := sess.Token()
+
+		// logging out without the refresh token revokes the session token only
+		revoker, err := client.RestoreSession(token, "")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(revoker.Logout(context.Background())).ShouldNot(HaveOccurred())
+		_, err = revoker.GetAccount(context.Background())
+		Expect(errors.Is(err, nakama_client_go.ErrUnauthenticated)).To(BeTrue())
+
+		account, err := sess.GetAccount(context.Background())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(account.User.Id).To(Equal(sess.UserID()))
+		Expect(sess.Token()).NotTo(Equal(token))
+	})
+	It("should refresh concurrently and re-decode the token", func() {
+		refreshes := &atomic.Int32{}
+		client := newNakamaHTTPClient().WithInterceptors(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			if method == "/nakama.api.Nakama/SessionRefresh" {
+				refreshes.Add(1)
+			}
+			return invoker(ctx, method, req, reply, cc, opts...)
+		})
+		authenticated, err := client.AuthenticateCustom(context.Background(), generateID(), nakama_client_go.AuthenticateOption{
+			Vars: map[string]string{"k": "v"},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		userID := authenticated.UserID()
+
+		expired := signSessionToken("defaultencryptionkey", map[string]any{
+			"uid": userID,
+			"exp": time.Now().Add(-time.Minute).Unix(),
+		})
+		sess, err := client.RestoreSession(expired, authenticated.RefreshToken())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(sess.IsExpired()).To(BeTrue())
+		Expect(sess.Var("k")).To(BeEmpty())
+
+		wg := &sync.WaitGroup{}
+		errs := make([]error, 8)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, errs[i] = sess.GetAccount(context.Background())
+			}(i)
+		}
+		wg.Wait()
+		for _, err := range errs {
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+
+		Expect(refreshes.Load()).To(Equal(int32(1)))
+		Expect(sess.IsExpired()).To(BeFalse())
+		Expect(sess.UserID()).To(Equal(userID))
+		Expect(sess.Var("k")).To(Equal("v"))
+		Expect(sess.RefreshToken()).NotTo(BeEmpty())
+	})
+	It("should refresh in background", func() {
+		sess, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+		token := sess.Token()
+
+		refreshed := make(chan *nakama_client_go.Session, 1)
+		sess.OnRefresh(func(s *nakama_client_go.Session) {
+			refreshed <- s
+		})
+		// the token lives for two hours, so any margin above that refreshes right away
+		stop, err := sess.RefreshInBackground(3 * time.Hour)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer stop()
+
+		Eventually(refreshed).Should(Receive())
+		Expect(sess.Token()).NotTo(Equal(token))
+	})
+	It("should restore a session from persisted tokens", func() {
+		client := newNakamaHTTPClient()
+		sess, err := client.AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		b, err := json.Marshal(sess)
+		Expect(err).ShouldNot(HaveOccurred())
+		var data nakama_client_go.SessionData
+		Expect(json.Unmarshal(b, &data)).ShouldNot(HaveOccurred())
+
+		restored, err := client.RestoreSessionData(data)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(restored.UserID()).To(Equal(sess.UserID()))
+		account, err := restored.GetAccount(context.Background())
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(account.User.Id).To(Equal(sess.UserID()))
+
+		_, err = client.RestoreSession("not a token", "")
+		Expect(err).Should(HaveOccurred())
+	})
+	It("should verify token signatures and expose claims", func() {
+		sess, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), generateID(), nakama_client_go.AuthenticateOption{
+			Vars: map[string]string{"level": "5"},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		verified, err := newNakamaHTTPClient().WithSessionVerificationKey("defaultencryptionkey").RestoreSession(sess.Token(), "")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(verified.UserID()).To(Equal(sess.UserID()))
+		Expect(verified.TokenID()).NotTo(BeEmpty())
+		Expect(verified.ExpiresAt().After(time.Now())).To(BeTrue())
+		// nakama tokens carry no iat claim
+		Expect(verified.IssuedAt().IsZero()).To(BeTrue())
+		level, err := nakama_client_go.VarAs[int](verified, "level")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(level).To(Equal(5))
+
+		_, err = newNakamaHTTPClient().WithSessionVerificationKey("wrongkey").RestoreSession(sess.Token(), "")
+		Expect(errors.Is(err, nakama_client_go.ErrInvalidToken)).To(BeTrue())
+		expired := signSessionToken("defaultencryptionkey", map[string]any{
+			"uid": sess.UserID(),
+			"exp": time.Now().Add(-time.Minute).Unix(),
+		})
+		_, err = newNakamaHTTPClient().WithSessionVerificationKey("defaultencryptionkey").RestoreSession(expired, "")
+		Expect(errors.Is(err, nakama_client_go.ErrSessionExpired)).To(BeTrue())
+	})
+	It("should compensate for clock skew", func() {
+		client := newNakamaHTTPClient().WithClock(skewedClock(2 * time.Hour))
+		sess, err := client.AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(client.ServerTimeOffset()).To(BeNumerically("~", -2*time.Hour, 5*time.Second))
+		Expect(sess.IsExpired()).To(BeFalse())
+		Expect(sess.IsRefreshExpired()).To(BeFalse())
+	})
+	It("should keep the server time offset near zero without skew", func() {
+		for _, client := range []*nakama_client_go.Client{newNakamaHTTPClient(), newNakamaGRPCClient()} {
+			sess, err := client.AuthenticateCustom(context.Background(), generateID())
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(client.ServerTimeOffset()).To(BeNumerically("~", 0, 5*time.Second))
+			Expect(sess.Refresh(context.Background())).ShouldNot(HaveOccurred())
+			Expect(client.ServerTimeOffset()).To(BeNumerically("~", 0, 5*time.Second))
+			Expect(sess.IsExpired()).To(BeFalse())
+		}
+	})
+})
+
+type skewedClock time.Duration
+
+func (c skewedClock) Now() time.Time {
+	return time.Now().Add(time.Duration(c))
+}
+
+// signSessionToken signs claims as an HS256 token, like nakama does with its encryption key.
+func signSessionToken(key string, claims map[string]any) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	body, _ := json.Marshal(claims)
+	signed := header + "." + base64.RawURLEncoding.EncodeToString(body)
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,187 @@
+package tests
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/rtapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nakama_client_go "github.com/joesonw/nakama-client-go"
+)
+
+var _ = Describe("Socket Tests", func() {
+	It("should serialize concurrent writes", func() {
+		sess, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+		sock, err := sess.NewSocket(context.Background(), false, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		stop, err := sock.KeepAlive(time.Millisecond)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer stop()
+		rc, err := sock.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+		rc.Start()
+
+		wg := &sync.WaitGroup{}
+		for i := 0; i < 16; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, err := rc.CreateMatch(context.Background(), generateID())
+				Expect(err).ShouldNot(HaveOccurred())
+			}()
+		}
+		wg.Wait()
+
+		Expect(sock.Flush(context.Background())).ShouldNot(HaveOccurred())
+		Expect(sock.Close()).ShouldNot(HaveOccurred())
+		Expect(sock.Flush(context.Background())).To(MatchError(ContainSubstring("socket closed")))
+	})
+	It("should reconnect and restore joined state", func() {
+		proxy := newDroppingProxy(nakamaHTTPEndpoint)
+		defer proxy.Close()
+		client := nakama_client_go.NewHTTPClient(proxy.Addr(), nakamaServerKey, false, nil)
+		sess, err := client.AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+		sock, err := sess.NewSocket(context.Background(), false, true)
+		Expect(err).ShouldNot(HaveOccurred())
+		rc, err := sock.Client()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		reconnecting := make(chan int, 8)
+		reconnected := make(chan *nakama_client_go.ReconnectResult, 1)
+		rc.WithReconnect(nakama_client_go.ReconnectOption{InitialBackoff: 10 * time.Millisecond}).
+			OnReconnecting(func(attempt int, err error) {
+				reconnecting <- attempt
+			}).
+			OnReconnected(func(result *nakama_client_go.ReconnectResult) {
+				reconnected <- result
+			})
+		rc.Start()
+		defer rc.Close()
+
+		// another user keeps the party and the relayed match alive while rc is away
+		other := newStartedRealtimeClient()
+		defer other.Close()
+		partyJoins := make(chan string, 4)
+		matchJoins := make(chan string, 4)
+		other.OnPartyPresence(func(ev *rtapi.PartyPresenceEvent) {
+			for _, p := range ev.Joins {
+				partyJoins <- p.UserId
+			}
+		}).OnMatchPresence(func(ev *rtapi.MatchPresenceEvent) {
+			for _, p := range ev.Joins {
+				matchJoins <- p.UserId
+			}
+		})
+
+		ctx := context.Background()
+		channel, err := rc.JoinChat(ctx, "lobby", rtapi.ChannelJoin_ROOM)
+		Expect(err).ShouldNot(HaveOccurred())
+		party, err := rc.CreateParty(ctx, true, 4)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(other.JoinParty(ctx, party.PartyId)).ShouldNot(HaveOccurred())
+		match, err := rc.CreateMatch(ctx, "")
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = other.JoinMatch(ctx, match.MatchId, "", nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		mode := generateID()
+		ticket, err := rc.AddMatchmaker(ctx, 2, 2, "+properties.mode:"+mode, map[string]string{"mode": mode}, nil)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		proxy.Drop()
+		Eventually(reconnecting).Should(Receive(Equal(1)))
+		var result *nakama_client_go.ReconnectResult
+		Eventually(reconnected, 5*time.Second).Should(Receive(&result))
+		Expect(result.Unrestored).To(BeEmpty())
+		Expect(result.Tickets).To(HaveKey(ticket.Ticket))
+		Expect(result.Tickets[ticket.Ticket]).NotTo(BeEmpty())
+		Expect(result.Tickets[ticket.Ticket]).NotTo(Equal(ticket.Ticket))
+
+		_, err = rc.WriteChatMessage(ctx, channel.Id, `{"hello":"world"}`)
+		Expect(err).ShouldNot(HaveOccurred())
+		Eventually(partyJoins).Should(Receive(Equal(sess.UserID())))
+		Eventually(matchJoins).Should(Receive(Equal(sess.UserID())))
+		Expect(rc.RemoveMatchmaker(ctx, result.Tickets[ticket.Ticket])).ShouldNot(HaveOccurred())
+
+		// the socket the client was created from is replaced
+		Expect(rc.Socket()).NotTo(BeIdenticalTo(sock))
+		Expect(sock.Flush(ctx)).Should(HaveOccurred())
+	})
+})
+
+func newStartedRealtimeClient() *nakama_client_go.RealtimeClient {
+	sess, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), generateID())
+	Expect(err).ShouldNot(HaveOccurred())
+	sock, err := sess.NewSocket(context.Background(), false, true)
+	Expect(err).ShouldNot(HaveOccurred())
+	rc, err := sock.Client()
+	Expect(err).ShouldNot(HaveOccurred())
+	rc.Start()
+	return rc
+}
+
+// droppingProxy forwards TCP connections to an address and can drop them all at once.
+type droppingProxy struct {
+	listener net.Listener
+	target   string
+	mu       *sync.Mutex
+	conns    []net.Conn
+}
+
+func newDroppingProxy(target string) *droppingProxy {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).ShouldNot(HaveOccurred())
+	p := &droppingProxy{listener: listener, target: target, mu: &sync.Mutex{}}
+	go p.serve()
+	return p
+}
+
+func (p *droppingProxy) Addr() string {
+	return p.listener.Addr().String()
+}
+
+func (p *droppingProxy) serve() {
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		upstream, err := net.Dial("tcp", p.target)
+		if err != nil {
+			_ = conn.Close()
+			continue
+		}
+		p.mu.Lock()
+		p.conns = append(p.conns, conn, upstream)
+		p.mu.Unlock()
+		go func() {
+			_, _ = io.Copy(upstream, conn)
+			_ = upstream.Close()
+		}()
+		go func() {
+			_, _ = io.Copy(conn, upstream)
+			_ = conn.Close()
+		}()
+	}
+}
+
+func (p *droppingProxy) Drop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, conn := range p.conns {
+		_ = conn.Close()
+	}
+	p.conns = nil
+}
+
+func (p *droppingProxy) Close() {
+	_ = p.listener.Close()
+	p.Drop()
+}
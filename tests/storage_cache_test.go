@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/heroiclabs/nakama-common/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	nakama_client_go "github.com/joesonw/nakama-client-go"
+)
+
+type offlineTransport struct {
+	offline *atomic.Bool
+}
+
+func (t offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.offline.Load() {
+		return nil, errors.New("offline")
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+var _ = Describe("Storage Cache Tests", func() {
+	It("should queue writes while offline and merge conflicts on flush", func() {
+		id := generateID()
+		offline := &atomic.Bool{}
+		client := nakama_client_go.NewHTTPClient(nakamaHTTPEndpoint, nakamaServerKey, false, &http.Client{
+			Transport: offlineTransport{offline: offline},
+		})
+		sess, err := client.AuthenticateCustom(context.Background(), id)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		cache, err := nakama_client_go.NewStorageCache(sess, GinkgoT().TempDir(), nakama_client_go.StorageCacheOption{
+			Resolver: nakama_client_go.MergeConflicts(mergeJSONObjects),
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		acks, err := cache.WriteStorageObjects(context.Background(), &api.WriteStorageObjectsRequest{
+			Objects: []*api.WriteStorageObject{{Collection: "notes", Key: "a", Value: `{"v":1}`}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// both queued writes are based on the first version, so both conflict on flush
+		offline.Store(true)
+		_, err = cache.WriteStorageObjects(context.Background(), &api.WriteStorageObjectsRequest{
+			Objects: []*api.WriteStorageObject{{Collection: "notes", Key: "a", Value: `{"v":2}`, Version: acks.Acks[0].Version}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = cache.WriteStorageObjects(context.Background(), &api.WriteStorageObjectsRequest{
+			Objects: []*api.WriteStorageObject{{Collection: "notes", Key: "a", Value: `{"v":3}`, Version: acks.Acks[0].Version}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cache.Pending()).To(Equal(2))
+
+		readReq := &api.ReadStorageObjectsRequest{
+			ObjectIds: []*api.ReadStorageObjectId{{Collection: "notes", Key: "a", UserId: sess.UserID()}},
+		}
+		res, err := cache.ReadStorageObjects(context.Background(), readReq)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res.Objects).To(HaveLen(1))
+		Expect(res.Objects[0].Value).To(Equal(`{"v":3}`))
+
+		other, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), id)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = other.WriteStorageObjects(context.Background(), &api.WriteStorageObjectsRequest{
+			Objects: []*api.WriteStorageObject{{Collection: "notes", Key: "a", Value: `{"v":0,"server":true}`}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		offline.Store(false)
+		cancelled, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(cache.Flush(cancelled)).Should(HaveOccurred())
+		Expect(cache.Pending()).To(Equal(2))
+
+		Expect(cache.Flush(context.Background())).ShouldNot(HaveOccurred())
+		Expect(cache.Pending()).To(Equal(0))
+
+		res, err = cache.ReadStorageObjects(context.Background(), readReq)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res.Objects[0].Value).To(MatchJSON(`{"v":3,"server":true}`))
+		server, err := sess.ReadStorageObjects(context.Background(), readReq)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(server.Objects[0].Value).To(MatchJSON(`{"v":3,"server":true}`))
+	})
+
+	It("should drop queued writes the server rejects and map flushed local versions", func() {
+		offline := &atomic.Bool{}
+		client := nakama_client_go.NewHTTPClient(nakamaHTTPEndpoint, nakamaServerKey, false, &http.Client{
+			Transport: offlineTransport{offline: offline},
+		})
+		sess, err := client.AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		var failed []*api.WriteStorageObject
+		cache, err := nakama_client_go.NewStorageCache(sess, GinkgoT().TempDir(), nakama_client_go.StorageCacheOption{
+			OnWriteFailed: func(write *api.WriteStorageObject, err error) {
+				failed = append(failed, write)
+			},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// clients can not write objects with no write permission again
+		_, err = cache.WriteStorageObjects(context.Background(), &api.WriteStorageObjectsRequest{
+			Objects: []*api.WriteStorageObject{{Collection: "locked", Key: "a", Value: `{"v":1}`, PermissionWrite: wrapperspb.Int32(0)}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		offline.Store(true)
+		_, err = cache.WriteStorageObjects(context.Background(), &api.WriteStorageObjectsRequest{
+			Objects: []*api.WriteStorageObject{{Collection: "locked", Key: "a", Value: `{"v":2}`}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		acks, err := cache.WriteStorageObjects(context.Background(), &api.WriteStorageObjectsRequest{
+			Objects: []*api.WriteStorageObject{{Collection: "notes", Key: "b", Value: `{"v":1}`}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cache.Pending()).To(Equal(2))
+
+		offline.Store(false)
+		Expect(cache.Flush(context.Background())).ShouldNot(HaveOccurred())
+		Expect(cache.Pending()).To(Equal(0))
+		Expect(failed).To(HaveLen(1))
+		Expect(failed[0].Collection).To(Equal("locked"))
+
+		res, err := cache.ReadStorageObjects(context.Background(), &api.ReadStorageObjectsRequest{
+			ObjectIds: []*api.ReadStorageObjectId{{Collection: "locked", Key: "a", UserId: sess.UserID()}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res.Objects[0].Value).To(MatchJSON(`{"v":1}`))
+
+		// the local version of the flushed write still refers to the object
+		_, err = cache.WriteStorageObjects(context.Background(), &api.WriteStorageObjectsRequest{
+			Objects: []*api.WriteStorageObject{{Collection: "notes", Key: "b", Value: `{"v":2}`, Version: acks.Acks[0].Version}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+})
+
+// mergeJSONObjects keeps the keys of both objects, local values win.
+func mergeJSONObjects(local, server string) (string, error) {
+	merged := map[string]any{}
+	if server != "" {
+		if err := json.Unmarshal([]byte(server), &merged); err != nil {
+			return "", err
+		}
+	}
+	if err := json.Unmarshal([]byte(local), &merged); err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(merged)
+	return string(b), err
+}
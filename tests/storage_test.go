@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/heroiclabs/nakama-common/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	nakama_client_go "github.com/joesonw/nakama-client-go"
+)
+
+type counter struct {
+	Count int `json:"count"`
+}
+
+var _ = Describe("Storage Tests", func() {
+	It("should create, update and detect conflicts", func() {
+		sess, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+		counters := nakama_client_go.NewCollection[counter](sess, "counters")
+
+		_, err = counters.Get(context.Background(), "a")
+		Expect(errors.Is(err, nakama_client_go.ErrNotFound)).To(BeTrue())
+
+		created, err := counters.Create(context.Background(), "a", counter{Count: 1})
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = counters.Create(context.Background(), "a", counter{Count: 1})
+		Expect(errors.Is(err, nakama_client_go.ErrAlreadyExists)).To(BeTrue())
+
+		_, err = counters.Put(context.Background(), "a", counter{Count: 2})
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = counters.PutIfMatch(context.Background(), "a", counter{Count: 3}, created.Version)
+		Expect(errors.Is(err, nakama_client_go.ErrVersionConflict)).To(BeTrue())
+
+		wg := &sync.WaitGroup{}
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, err := counters.Update(context.Background(), "a", func(value *counter) error {
+					value.Count++
+					return nil
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+			}()
+		}
+		wg.Wait()
+
+		obj, err := counters.Get(context.Background(), "a")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(obj.Value.Count).To(Equal(6))
+		Expect(counters.Delete(context.Background(), "a", obj.Version)).ShouldNot(HaveOccurred())
+	})
+	It("should migrate values on read and write them back", func() {
+		sess, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = nakama_client_go.NewCollection[profileV1](sess, "profiles").Put(context.Background(), "me", profileV1{Name: "joe"})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		migrations := nakama_client_go.NewMigrations(2).Register(1, func(value map[string]any) error {
+			value["display_name"] = value["name"]
+			delete(value, "name")
+			return nil
+		})
+		profiles := nakama_client_go.NewCollection[profileV2](sess, "profiles", nakama_client_go.CollectionOption{
+			Migrations:          migrations,
+			WriteBackMigrations: true,
+		})
+		obj, err := profiles.Get(context.Background(), "me")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(obj.Value.DisplayName).To(Equal("joe"))
+
+		res, err := sess.ReadStorageObjects(context.Background(), &api.ReadStorageObjectsRequest{
+			ObjectIds: []*api.ReadStorageObjectId{{Collection: "profiles", Key: "me", UserId: sess.UserID()}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res.Objects).To(HaveLen(1))
+		Expect(res.Objects[0].Version).To(Equal(obj.Version))
+		Expect(res.Objects[0].Value).To(MatchJSON(`{"_schema":2,"display_name":"joe"}`))
+	})
+	It("should keep the permissions of migrated objects", func() {
+		sess, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+		publicRead := int32(2)
+		_, err = nakama_client_go.NewCollection[profileV1](sess, "profiles", nakama_client_go.CollectionOption{
+			PermissionRead: &publicRead,
+		}).Put(context.Background(), "me", profileV1{Name: "joe"})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		profiles := nakama_client_go.NewCollection[profileV2](sess, "profiles", nakama_client_go.CollectionOption{
+			Migrations: nakama_client_go.NewMigrations(2).Register(1, func(value map[string]any) error {
+				value["display_name"] = value["name"]
+				return nil
+			}),
+			WriteBackMigrations: true,
+		})
+		obj, err := profiles.Get(context.Background(), "me")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(obj.PermissionRead).To(Equal(publicRead))
+
+		res, err := sess.ReadStorageObjects(context.Background(), &api.ReadStorageObjectsRequest{
+			ObjectIds: []*api.ReadStorageObjectId{{Collection: "profiles", Key: "me", UserId: sess.UserID()}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res.Objects[0].Version).To(Equal(obj.Version))
+		Expect(res.Objects[0].PermissionRead).To(Equal(publicRead))
+		Expect(res.Objects[0].PermissionWrite).To(Equal(int32(1)))
+	})
+	It("should encrypt values and rotate keys", func() {
+		sess, err := newNakamaHTTPClient().AuthenticateCustom(context.Background(), generateID())
+		Expect(err).ShouldNot(HaveOccurred())
+		encryption := nakama_client_go.NewStorageEncryption("k1", []byte("first secret"))
+		storage := sess.EncryptedStorage(encryption)
+
+		_, err = storage.WriteStorageObjects(context.Background(), &api.WriteStorageObjectsRequest{
+			Objects: []*api.WriteStorageObject{{Collection: "notes", Key: "a", Value: `{"count":1}`}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		req := &api.ReadStorageObjectsRequest{
+			ObjectIds: []*api.ReadStorageObjectId{{Collection: "notes", Key: "a", UserId: sess.UserID()}},
+		}
+		raw, err := sess.ReadStorageObjects(context.Background(), req)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(raw.Objects[0].Value).NotTo(ContainSubstring("count"))
+
+		rotated := nakama_client_go.NewStorageEncryption("k2", []byte("second secret")).WithKey("k1", []byte("first secret"))
+		n, err := sess.EncryptedStorage(rotated).Rotate(context.Background(), "notes")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(n).To(Equal(1))
+
+		_, err = storage.ReadStorageObjects(context.Background(), req)
+		Expect(errors.Is(err, nakama_client_go.ErrDecryptionFailed)).To(BeTrue())
+		notes := nakama_client_go.NewCollection[counter](sess, "notes", nakama_client_go.CollectionOption{
+			Encryption: rotated,
+		})
+		obj, err := notes.Get(context.Background(), "a")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(obj.Value.Count).To(Equal(1))
+
+		// plaintext is only accepted when allowed
+		_, err = sess.WriteStorageObjects(context.Background(), &api.WriteStorageObjectsRequest{
+			Objects: []*api.WriteStorageObject{{Collection: "notes", Key: "a", Value: `{"count":2}`}},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = notes.Get(context.Background(), "a")
+		Expect(errors.Is(err, nakama_client_go.ErrDecryptionFailed)).To(BeTrue())
+		res, err := sess.EncryptedStorage(rotated.AllowPlaintext()).ReadStorageObjects(context.Background(), req)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(res.Objects[0].Value).To(MatchJSON(`{"count":2}`))
+	})
+})
+
+type profileV1 struct {
+	Name string `json:"name"`
+}
+
+type profileV2 struct {
+	DisplayName string `json:"display_name"`
+}
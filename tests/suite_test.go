@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/efficientgo/core/testutil"
@@ -41,6 +42,9 @@ func TestAll(t *testing.T) {
 	testutil.Ok(t, db.Start())
 	testutil.Ok(t, db.WaitReady())
 
+	modulesDir, err := filepath.Abs("../modules")
+	testutil.Ok(t, err)
+
 	dbAddr := db.InternalEndpoint("grpc")
 	nakama := e.Runnable("nakama").
 		WithPorts(map[string]int{
@@ -53,6 +57,7 @@ func TestAll(t *testing.T) {
 			User:      "",
 			Command:   e2e.NewCommandWithoutEntrypoint("/bin/sh", "-ecx", fmt.Sprintf("/nakama/nakama migrate up --database.address root@%s && exec /nakama/nakama --name nakama --database.address root@%s --logger.level DEBUG --session.token_expiry_sec 7200", dbAddr, dbAddr)),
 			Readiness: e2e.NewCmdReadinessProbe(e2e.NewCommand("/nakama/nakama", "healthcheck")),
+			Volumes:   []string{modulesDir + ":/nakama/data/modules"},
 		})
 	testutil.Ok(t, e2e.StartAndWaitReady(nakama))
 
@@ -67,6 +72,12 @@ func newNakamaHTTPClient() *nakama_client_go.Client {
 	return nakama_client_go.NewHTTPClient(nakamaHTTPEndpoint, nakamaServerKey, false, nil)
 }
 
+func newNakamaGRPCClient() *nakama_client_go.Client {
+	client, err := nakama_client_go.NewGRPCClient(nakamaGRPCEndpoint, nakamaServerKey, false)
+	Expect(err).ShouldNot(HaveOccurred())
+	return client
+}
+
 //nolint:unused
 func createFacebookInstantGameAuthToken(id string) string {
 	testSecret := "fb-instant-test-secret"
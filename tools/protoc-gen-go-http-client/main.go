@@ -65,6 +65,14 @@ func main() {
 				g.P("type ", client, " struct {")
 				g.P("Client *", pkgHTTP.Ident("Client"))
 				g.P("URL string")
+				g.P("Interceptor ", pkgGRPC.Ident("UnaryClientInterceptor"))
+				g.P("}")
+				g.P("")
+				g.P("func (c *", client, ") invoke(ctx ", pkgContext.Ident("Context"), ", method string, req, res interface{}, invoker ", pkgGRPC.Ident("UnaryInvoker"), ", opts ...", pkgGRPC.Ident("CallOption"), ") error {")
+				g.P("if c.Interceptor == nil {")
+				g.P("return invoker(ctx, method, req, res, nil, opts...)")
+				g.P("}")
+				g.P("return c.Interceptor(ctx, method, req, res, nil, invoker, opts...)")
 				g.P("}")
 				g.P("")
 
@@ -104,7 +112,20 @@ func main() {
 							methodName += strconv.Itoa(i + 1)
 						}
 
+						fullMethod := fmt.Sprintf("/%s/%s", s.Desc.FullName(), m.Desc.Name())
 						g.P("func (c *", client, ") ", methodName, "(ctx ", pkgContext.Ident("Context"), ", req *", m.Input.GoIdent, ", opts ...", pkgGRPC.Ident("CallOption"), ") (*", m.Output.GoIdent, ", error) {")
+						g.P("res := &", m.Output.GoIdent, "{}")
+						g.P("invoker := func(ctx ", pkgContext.Ident("Context"), ", method string, req, res interface{}, cc *", pkgGRPC.Ident("ClientConn"), ", opts ...", pkgGRPC.Ident("CallOption"), ") error {")
+						g.P("return c.do", methodName, "(ctx, req.(*", m.Input.GoIdent, "), res.(*", m.Output.GoIdent, "), opts...)")
+						g.P("}")
+						g.P("if err := c.invoke(ctx, ", strconv.Quote(fullMethod), ", req, res, invoker, opts...); err != nil {")
+						g.P("return nil, err")
+						g.P("}")
+						g.P("return res, nil")
+						g.P("}")
+						g.P("")
+
+						g.P("func (c *", client, ") do", methodName, "(ctx ", pkgContext.Ident("Context"), ", req *", m.Input.GoIdent, ", res *", m.Output.GoIdent, ", opts ...", pkgGRPC.Ident("CallOption"), ") error {")
 						g.P("var body ", pkgIO.Ident("Reader"))
 						g2.P(methodName, "(ctx ", pkgContext.Ident("Context"), ", req *", m.Input.GoIdent, ", opts ...", pkgGRPC.Ident("CallOption"), ") (*", m.Output.GoIdent, ", error)")
 
@@ -148,14 +169,14 @@ func main() {
 								g.P("reqBytes, err := ", pkgJSON.Ident("Marshal"), "(req", fieldName, ")")
 							}
 							g.P("if err != nil {")
-							g.P("return nil, err")
+							g.P("return err")
 							g.P("}")
 							g.P("body = ", pkgBytes.Ident("NewReader"), "(reqBytes)")
 						}
 
 						g.P("u, err := ", pkgURL.Ident("Parse"), "(c.URL + path)")
 						g.P("if err != nil {")
-						g.P("return nil, err")
+						g.P("return err")
 						g.P("}")
 
 						g.P("q:= &", pkgURL.Ident("Values"), "{}")
@@ -193,9 +214,8 @@ func main() {
 
 						g.P("httpReq, err := ", pkgHTTP.Ident("NewRequestWithContext"), "(ctx, ", strconv.Quote(method), ", u.String(), body)")
 						g.P("if err != nil {")
-						g.P("return nil, err")
+						g.P("return err")
 						g.P("}")
-						g.P("httpReq = ", pkgHTTPUtil.Ident("AttachGRPCToRequest"), "(ctx, httpReq, opts...)")
 						g.P("if body != nil {")
 						g.P("httpReq.Header.Set(\"Content-Type\", \"application/json\")")
 						g.P("}")
@@ -203,36 +223,36 @@ func main() {
 						for _, security := range operation.GetSecurity() {
 							if _, ok := security.GetSecurityRequirement()["BasicAuth"]; ok {
 								g.P("user, pass := ", pkgHTTPUtil.Ident("GetBasicAuth"), "(ctx)")
-								g.P("httpReq.Header.Add(\"Authorization\",\"Basic \" + ", pkgBase64.Ident("StdEncoding"), ".EncodeToString([]byte(user+\":\"+pass)))")
+								g.P("httpReq.Header.Set(\"Authorization\",\"Basic \" + ", pkgBase64.Ident("StdEncoding"), ".EncodeToString([]byte(user+\":\"+pass)))")
 							}
 							if _, ok := security.GetSecurityRequirement()["BearerJwt"]; ok {
-								g.P("httpReq.Header.Add(\"Authorization\", \"Bearer \" + ", pkgHTTPUtil.Ident("GetBearerJWT"), "(ctx))")
+								g.P("httpReq.Header.Set(\"Authorization\", \"Bearer \" + ", pkgHTTPUtil.Ident("GetBearerJWT"), "(ctx))")
 							}
 						}
 						if operation == nil {
-							g.P("httpReq.Header.Add(\"Authorization\", \"Bearer \" + ", pkgHTTPUtil.Ident("GetBearerJWT"), "(ctx))")
+							g.P("httpReq.Header.Set(\"Authorization\", \"Bearer \" + ", pkgHTTPUtil.Ident("GetBearerJWT"), "(ctx))")
 						}
 
+						g.P("httpReq, err = ", pkgHTTPUtil.Ident("AttachGRPCToRequest"), "(ctx, httpReq, opts...)")
+						g.P("if err != nil {")
+						g.P("return err")
+						g.P("}")
+
 						g.P("httpRes, err := c.Client.Do(httpReq)")
 						g.P("if err != nil {")
-						g.P("return nil, err")
+						g.P("return err")
 						g.P("}")
+						g.P("defer ", pkgHTTPUtil.Ident("RetrieveGRPCFromResponse"), "(ctx, httpRes, opts...)")
 						g.P("if err := ", pkgHTTPUtil.Ident("CheckResponse"), "(ctx, httpRes); err != nil {")
 						g.P("_ = httpRes.Body.Close()")
-						g.P("return nil, err")
+						g.P("return err")
 						g.P("}")
-						g.P("httpRes = ", pkgHTTPUtil.Ident("RetrieveGRPCFromResponse"), "(ctx, httpRes, opts...)")
 						g.P("resBytes, err := ", pkgIO.Ident("ReadAll"), "(httpRes.Body)")
 						g.P("_ = httpRes.Body.Close()")
 						g.P("if err != nil {")
-						g.P("return nil, err")
-						g.P("}")
-						g.P("res := &", m.Output.GoIdent, "{}")
-						g.P("err = ", pkgProtoJSON.Ident("Unmarshal"), "(resBytes, res)")
-						g.P("if err != nil {")
-						g.P("return nil, err")
+						g.P("return err")
 						g.P("}")
-						g.P("return res, nil")
+						g.P("return ", pkgProtoJSON.Ident("Unmarshal"), "(resBytes, res)")
 						g.P("}")
 						g.P("")
 						_ = rule
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	securityBasic  = "BasicAuth"
+	securityBearer = "BearerJwt"
+
+	messageEmpty   = "google.protobuf.Empty"
+	messageSession = "nakama.api.Session"
+)
+
+var (
+	pkgContext  = protogen.GoImportPath("context")
+	pkgEmptyPB  = protogen.GoImportPath("google.golang.org/protobuf/types/known/emptypb")
+	pkgHTTPUtil = protogen.GoImportPath("github.com/joesonw/nakama-client-go/httputil")
+	pkgClient   = protogen.GoImportPath("github.com/joesonw/nakama-client-go")
+
+	// wrappers exposed under a different name than the rpc
+	renamedMethods = map[string]string{
+		"Event":   "EmitEvent",
+		"RpcFunc": "RPC",
+	}
+
+	// wrappers kept by hand in the root package, they manage session state or have friendlier
+	// signatures. RpcFunc is the only method authorized by the http_key, so ServerClient is
+	// entirely hand-written.
+	handwrittenMethods = map[string]bool{
+		"Session.SessionLogout": true,
+		"Session.DeleteAccount": true,
+		"Session.RpcFunc":       true,
+		"Client.SessionRefresh": true,
+	}
+)
+
+type receiver struct {
+	name string
+	// expression of the api client from the receiver s
+	api string
+	// prepares ctx for the call, declaring err if errDeclared
+	prologue    func(g *protogen.GeneratedFile, hasResult bool)
+	errDeclared bool
+	// turns a nakama.api.Session res into a *Session, methods returning one are generated
+	// unexported for hand-written wrappers with friendlier signatures
+	newSession string
+}
+
+var receivers = map[string]receiver{
+	securityBearer: {
+		name:        "Session",
+		api:         "s.client.api",
+		errDeclared: true,
+		prologue: func(g *protogen.GeneratedFile, hasResult bool) {
+			g.P("ctx, err := s.attachSessionContext(ctx)")
+			g.P("if err != nil {")
+			if hasResult {
+				g.P("return nil, err")
+			} else {
+				g.P("return err")
+			}
+			g.P("}")
+		},
+	},
+	securityBasic: {
+		name:       "Client",
+		api:        "s.api",
+		newSession: "s.newAuthenticatedSession",
+		prologue: func(g *protogen.GeneratedFile, hasResult bool) {
+			g.P("ctx = ", pkgHTTPUtil.Ident("WithBasicAuth"), "(ctx, s.serverKey, \"\")")
+		},
+	},
+}
+
+var receiverOrder = []string{securityBearer, securityBasic}
+
+// securities of the method, methods without an openapi operation default to the bearer token
+func securities(m *protogen.Method) map[string]bool {
+	operation, _ := proto.GetExtension(m.Desc.Options(), options.E_Openapiv2Operation).(*options.Operation)
+	if operation == nil || len(operation.GetSecurity()) == 0 {
+		return map[string]bool{securityBearer: true}
+	}
+	result := map[string]bool{}
+	for _, security := range operation.GetSecurity() {
+		for name := range security.GetSecurityRequirement() {
+			result[name] = true
+		}
+	}
+	return result
+}
+
+func main() {
+	protogen.Options{
+		ParamFunc: flag.CommandLine.Set,
+	}.Run(func(gen *protogen.Plugin) error {
+		for _, f := range gen.Files {
+			if !f.Generate {
+				continue
+			}
+			g := gen.NewGeneratedFile(f.GeneratedFilenamePrefix+".pb.client.go", pkgClient)
+			g.P("// generated by protoc-gen-go-session-client. PLEASE DO NOT MODIFY")
+			g.P("")
+			g.P("package nakama_client_go")
+			g.P("")
+
+			for _, s := range f.Services {
+				for _, security := range receiverOrder {
+					recv := receivers[security]
+					for _, m := range s.Methods {
+						returnsSession := m.Output.Desc.FullName() == messageSession
+						if !securities(m)[security] || (returnsSession && recv.newSession == "") || handwrittenMethods[recv.name+"."+m.GoName] {
+							continue
+						}
+						generateMethod(g, recv, m)
+					}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func generateMethod(g *protogen.GeneratedFile, recv receiver, m *protogen.Method) {
+	name := m.GoName
+	if renamed, ok := renamedMethods[name]; ok {
+		name = renamed
+	}
+	hasRequest := m.Input.Desc.FullName() != messageEmpty
+	hasResult := m.Output.Desc.FullName() != messageEmpty
+	returnsSession := m.Output.Desc.FullName() == messageSession
+	if returnsSession {
+		name = strings.ToLower(name[:1]) + name[1:]
+	}
+
+	args := []interface{}{"func (s *", recv.name, ") ", name, "(ctx ", pkgContext.Ident("Context")}
+	if hasRequest {
+		args = append(args, ", req *", m.Input.GoIdent)
+	}
+	args = append(args, ", opts ...CallOption)")
+	switch {
+	case returnsSession:
+		args = append(args, " (*Session, error)")
+	case hasResult:
+		args = append(args, " (*", m.Output.GoIdent, ", error)")
+	default:
+		args = append(args, " error")
+	}
+	args = append(args, " {")
+	g.P(args...)
+
+	g.P("ctx, cancel, callOpts := applyCallOptions(ctx, opts...)")
+	g.P("defer cancel()")
+	recv.prologue(g, hasResult)
+
+	req := "req"
+	if !hasRequest {
+		req = "&" + g.QualifiedGoIdent(pkgEmptyPB.Ident("Empty")) + "{}"
+	}
+	switch {
+	case returnsSession:
+		g.P("res, err := ", recv.api, ".", m.GoName, "(ctx, ", req, ", callOpts...)")
+		g.P("if err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P("return ", recv.newSession, "(ctx, res)")
+	case hasResult:
+		g.P("return ", recv.api, ".", m.GoName, "(ctx, ", req, ", callOpts...)")
+	case recv.errDeclared:
+		g.P("_, err = ", recv.api, ".", m.GoName, "(ctx, ", req, ", callOpts...)")
+		g.P("return err")
+	default:
+		g.P("_, err := ", recv.api, ".", m.GoName, "(ctx, ", req, ", callOpts...)")
+		g.P("return err")
+	}
+	g.P("}")
+	g.P("")
+}